@@ -0,0 +1,78 @@
+package numeral
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Allocator hands out the values of a named sequence shared through a Store.
+// It leases a block of values at a time and issues them locally with
+// Increment until the block runs out, so processes sharing a store never issue
+// the same value and only talk to it once per block. It is safe for
+// concurrent use.
+type Allocator struct {
+	mu        sync.Mutex
+	store     Store
+	name      string
+	values    []rune
+	blockSize uint64
+	current   *Numeral
+	end       *big.Int
+	remaining uint64
+}
+
+// NewAllocator creates an allocator for the named sequence of store that
+// leases blockSize values at a time and renders them with values.
+func NewAllocator(store Store, name string, values []rune, blockSize uint64) (*Allocator, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed for an allocator, got: %d", len(values))
+	}
+	if blockSize == 0 {
+		return nil, fmt.Errorf("numeral: invalid block size: %d", blockSize)
+	}
+	return &Allocator{
+		store:     store,
+		name:      name,
+		values:    values,
+		blockSize: blockSize,
+	}, nil
+}
+
+// Next returns a value that no other allocator of the sequence issues,
+// leasing a new block when the current one has run out.
+func (a *Allocator) Next() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.remaining == 0 {
+		block, err := a.store.Lease(a.name, a.blockSize)
+		if err != nil {
+			return "", err
+		}
+		current, err := newFromBig(a.values, block.Start)
+		if err != nil {
+			return "", err
+		}
+		a.current, a.end, a.remaining = current, block.End, block.size().Uint64()
+	}
+	s := a.current.String()
+	a.current.Increment()
+	a.remaining--
+	return s, nil
+}
+
+// Close returns the unused tail of the current block to the store, so that it
+// is leased again instead of being lost.
+func (a *Allocator) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.remaining == 0 {
+		return nil
+	}
+	tail := Block{Start: a.current.bigInt(), End: a.end}
+	if err := a.store.Return(a.name, tail); err != nil {
+		return err
+	}
+	a.remaining = 0
+	return nil
+}
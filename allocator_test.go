@@ -0,0 +1,150 @@
+package numeral_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func testStores(t *testing.T) (map[string]func() numeral.Store, func()) {
+	path, cleanup := tempCounterPath(t)
+	path = filepath.Join(filepath.Dir(path), "store.json")
+	memory := numeral.NewMemoryStore()
+	return map[string]func() numeral.Store{
+		"memory": func() numeral.Store { return memory },
+		"file":   func() numeral.Store { return numeral.NewFileStore(path) },
+	}, cleanup
+}
+
+func TestAllocatorReturnsUnusedTails(t *testing.T) {
+	stores, cleanup := testStores(t)
+	defer cleanup()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			a, _ := numeral.NewAllocator(store(), "codes", testValues, 10)
+			for _, want := range []string{"0", "1", "2"} {
+				got, err := a.Next()
+				if err != nil {
+					t.Fatalf("expected nil got err: %v", err)
+				}
+				if got != want {
+					t.Errorf("got: %s want: %s", got, want)
+				}
+			}
+			// a second process leases the next block.
+			b, _ := numeral.NewAllocator(store(), "codes", testValues, 10)
+			if got, _ := b.Next(); got != "a" {
+				t.Errorf("got: %s want: a", got)
+			}
+			if err := a.Close(); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			tails, err := store().Tails("codes")
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if len(tails) != 1 || tails[0].Start.Int64() != 3 || tails[0].End.Int64() != 9 {
+				t.Fatalf("got: %v want: one tail from 3 to 9", tails)
+			}
+			// the tail is leased again before fresh values.
+			c, _ := numeral.NewAllocator(store(), "codes", testValues, 5)
+			for _, want := range []string{"3", "4", "5", "6", "7", "8", "9", "k"} {
+				if got, _ := c.Next(); got != want {
+					t.Errorf("got: %s want: %s", got, want)
+				}
+			}
+			if tails, _ := store().Tails("codes"); len(tails) != 0 {
+				t.Errorf("got: %v want no tails", tails)
+			}
+		})
+	}
+}
+
+func TestAllocatorConcurrent(t *testing.T) {
+	stores, cleanup := testStores(t)
+	defer cleanup()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			const workers, perWorker = 4, 200
+			var mu sync.Mutex
+			seen := make(map[string]bool)
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					a, _ := numeral.NewAllocator(store(), "concurrent", decimalValues, 7)
+					defer a.Close()
+					for i := 0; i < perWorker; i++ {
+						v, err := a.Next()
+						if err != nil {
+							t.Errorf("expected nil got err: %v", err)
+							return
+						}
+						mu.Lock()
+						if seen[v] {
+							t.Errorf("got: %s twice", v)
+						}
+						seen[v] = true
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+			if len(seen) != workers*perWorker {
+				t.Errorf("got: %d values want: %d", len(seen), workers*perWorker)
+			}
+		})
+	}
+}
+
+func TestStoreReturnInvalid(t *testing.T) {
+	stores, cleanup := testStores(t)
+	defer cleanup()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := store()
+			if _, err := s.Lease("invalid", 0); err == nil {
+				t.Error("expected error to be thrown on an empty lease")
+			}
+			s.Lease("invalid", 10)
+			never := numeral.Block{Start: big.NewInt(5), End: big.NewInt(20)}
+			if err := s.Return("invalid", never); err == nil {
+				t.Error("expected error to be thrown on returning values that were never leased")
+			}
+			if _, err := numeral.NewAllocator(s, "invalid", decimalValues, 0); err == nil {
+				t.Error("expected error to be thrown on an empty block size")
+			}
+		})
+	}
+}
+
+func TestStoreReturnTwice(t *testing.T) {
+	stores, cleanup := testStores(t)
+	defer cleanup()
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			s := store()
+			s.Lease("twice", 10)
+			tail := numeral.Block{Start: big.NewInt(5), End: big.NewInt(9)}
+			if err := s.Return("twice", tail); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if err := s.Return("twice", tail); err == nil {
+				t.Error("expected error to be thrown on returning a block twice")
+			}
+			overlapping := numeral.Block{Start: big.NewInt(3), End: big.NewInt(6)}
+			if err := s.Return("twice", overlapping); err == nil {
+				t.Error("expected error to be thrown on returning an overlapping block")
+			}
+			first, _ := s.Lease("twice", 5)
+			second, _ := s.Lease("twice", 5)
+			if first.Start.Int64() != 5 || first.End.Int64() != 9 || second.Start.Int64() != 10 || second.End.Int64() != 14 {
+				t.Errorf("got: %v and %v want: [5, 9] and [10, 14]", first, second)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package numeral
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// ErrOverflow is returned when a counter has issued every value that fits in
+// its width.
+var ErrOverflow = errors.New("numeral: counter overflows its width")
+
+// maxAtomicWidth is the largest width an atomic counter renders, enough for
+// any 128 bit value in binary.
+const maxAtomicWidth = 128
+
+// maxAtomicValues is the largest amount of digit values an atomic counter
+// renders, as appendFixed keeps digit positions in 16 bits.
+const maxAtomicValues = 1 << 16
+
+// AtomicCounter is a lock free counter of fixed width numerals, backed by a
+// uint64. Unlike Counter it does not keep a Numeral around: values are plain
+// integers that are only rendered in its digit values on demand, and
+// rendering into a buffer provided by the caller does not allocate.
+type AtomicCounter struct {
+	// value is accessed atomically and kept first for 64 bit alignment.
+	value  uint64
+	limit  uint64
+	full   bool
+	values []rune
+	ascii  bool
+	width  int
+}
+
+// NewAtomicCounter creates a counter whose first value is start, rendered with
+// width digits of values. Next returns ErrOverflow once every value that fits
+// in width digits has been issued. When width digits hold more than a uint64,
+// the largest uint64 is where the counter stops instead.
+func NewAtomicCounter(values []rune, width int, start uint64) (*AtomicCounter, error) {
+	limit, err := atomicLimit(values, width)
+	if err != nil {
+		return nil, err
+	}
+	c := &AtomicCounter{
+		value:  start,
+		values: values,
+		ascii:  isASCII(values),
+		width:  width,
+	}
+	if limit.BitLen() > 64 {
+		c.full = true
+	} else {
+		c.limit = limit.Uint64()
+		if start >= c.limit {
+			return nil, fmt.Errorf("numeral: start %d does not fit in %d digits", start, width)
+		}
+	}
+	return c, nil
+}
+
+// Next returns the current value of the counter and advances it by one.
+func (c *AtomicCounter) Next() (uint64, error) {
+	for {
+		v := atomic.LoadUint64(&c.value)
+		if (!c.full && v >= c.limit) || (c.full && v == 1<<64-1) {
+			// the largest uint64 is never issued, as the counter would
+			// wrap around after it.
+			return 0, ErrOverflow
+		}
+		if atomic.CompareAndSwapUint64(&c.value, v, v+1) {
+			return v, nil
+		}
+	}
+}
+
+// Peek returns the value that the next call to Next would return.
+func (c *AtomicCounter) Peek() uint64 {
+	return atomic.LoadUint64(&c.value)
+}
+
+// AppendNext appends the rendering of Next to dst and returns the extended
+// buffer.
+func (c *AtomicCounter) AppendNext(dst []byte) ([]byte, error) {
+	v, err := c.Next()
+	if err != nil {
+		return dst, err
+	}
+	return c.Append(dst, v)
+}
+
+// NextString returns the rendering of Next.
+func (c *AtomicCounter) NextString() (string, error) {
+	var buf [maxAtomicWidth]byte
+	b, err := c.AppendNext(buf[:0])
+	return string(b), err
+}
+
+// Append appends v, rendered with the width and the digit values of the
+// counter, to dst and returns the extended buffer. It returns dst unchanged
+// and an error when v has more digits than the width.
+func (c *AtomicCounter) Append(dst []byte, v uint64) ([]byte, error) {
+	return c.AppendUint128(dst, Uint128{Lo: v})
+}
+
+// AppendUint128 is like Append for values of up to 128 bits.
+func (c *AtomicCounter) AppendUint128(dst []byte, v Uint128) ([]byte, error) {
+	return appendFixed(dst, c.values, c.ascii, c.width, v)
+}
+
+// Uint128 is an unsigned 128 bit integer.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// String returns the decimal form of u.
+func (u Uint128) String() string {
+	x := new(big.Int).SetUint64(u.Hi)
+	x.Lsh(x, 64)
+	return x.Or(x, new(big.Int).SetUint64(u.Lo)).String()
+}
+
+// AtomicCounter128 is the 128 bit variant of AtomicCounter, e.g. for UUID
+// sized IDs. There are no 128 bit atomic instructions, so the value is kept
+// in two words guarded by a sequence lock: Next increments the low word with
+// compare and swap like AtomicCounter, and only a carry into the high word,
+// once every 2^64 values, takes the lock and makes the other goroutines spin
+// until it is done. In exchange Next does not allocate.
+type AtomicCounter128 struct {
+	// seq, hi and lo are accessed atomically and kept first for 64 bit
+	// alignment. seq is odd while a carry updates hi and lo.
+	seq    uint64
+	hi, lo uint64
+	limit  Uint128
+	full   bool
+	values []rune
+	ascii  bool
+	width  int
+}
+
+// NewAtomicCounter128 creates a counter whose first value is start, rendered
+// with width digits of values.
+func NewAtomicCounter128(values []rune, width int, start Uint128) (*AtomicCounter128, error) {
+	limit, err := atomicLimit(values, width)
+	if err != nil {
+		return nil, err
+	}
+	c := &AtomicCounter128{
+		hi:     start.Hi,
+		lo:     start.Lo,
+		values: values,
+		ascii:  isASCII(values),
+		width:  width,
+	}
+	if limit.BitLen() > 128 {
+		c.full = true
+	} else {
+		mask := new(big.Int).SetUint64(1<<64 - 1)
+		c.limit = Uint128{
+			Hi: new(big.Int).Rsh(limit, 64).Uint64(),
+			Lo: new(big.Int).And(limit, mask).Uint64(),
+		}
+		if !start.less(c.limit) {
+			return nil, fmt.Errorf("numeral: start %s does not fit in %d digits", start.String(), width)
+		}
+	}
+	return c, nil
+}
+
+// less reports whether u is smaller than v.
+func (u Uint128) less(v Uint128) bool {
+	return u.Hi < v.Hi || (u.Hi == v.Hi && u.Lo < v.Lo)
+}
+
+// load returns the value of the counter along with the sequence it was read
+// at, waiting for a carry in progress to finish.
+func (c *AtomicCounter128) load() (Uint128, uint64) {
+	for {
+		seq := atomic.LoadUint64(&c.seq)
+		if seq&1 == 1 {
+			runtime.Gosched()
+			continue
+		}
+		v := Uint128{Hi: atomic.LoadUint64(&c.hi), Lo: atomic.LoadUint64(&c.lo)}
+		if atomic.LoadUint64(&c.seq) == seq {
+			return v, seq
+		}
+	}
+}
+
+// Next returns the current value of the counter and advances it by one.
+func (c *AtomicCounter128) Next() (Uint128, error) {
+	for {
+		v, seq := c.load()
+		if (!c.full && !v.less(c.limit)) || (c.full && v.Hi == 1<<64-1 && v.Lo == 1<<64-1) {
+			return Uint128{}, ErrOverflow
+		}
+		if v.Lo != 1<<64-1 {
+			// the low word can only be back at v.Lo after 2^64 values.
+			if atomic.CompareAndSwapUint64(&c.lo, v.Lo, v.Lo+1) {
+				return v, nil
+			}
+			continue
+		}
+		// the low word is stuck at its largest value until the carry, which
+		// the goroutine that takes the lock does.
+		if atomic.CompareAndSwapUint64(&c.seq, seq, seq+1) {
+			atomic.StoreUint64(&c.hi, v.Hi+1)
+			atomic.StoreUint64(&c.lo, 0)
+			atomic.StoreUint64(&c.seq, seq+2)
+			return v, nil
+		}
+	}
+}
+
+// Peek returns the value that the next call to Next would return.
+func (c *AtomicCounter128) Peek() Uint128 {
+	v, _ := c.load()
+	return v
+}
+
+// AppendNext appends the rendering of Next to dst and returns the extended
+// buffer.
+func (c *AtomicCounter128) AppendNext(dst []byte) ([]byte, error) {
+	v, err := c.Next()
+	if err != nil {
+		return dst, err
+	}
+	return appendFixed(dst, c.values, c.ascii, c.width, v)
+}
+
+// NextString returns the rendering of Next.
+func (c *AtomicCounter128) NextString() (string, error) {
+	var buf [maxAtomicWidth]byte
+	b, err := c.AppendNext(buf[:0])
+	return string(b), err
+}
+
+// atomicLimit validates the settings of an atomic counter and returns the
+// amount of values that fit in width digits.
+func atomicLimit(values []rune, width int) (*big.Int, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed for a counter, got: %d", len(values))
+	}
+	if len(values) > maxAtomicValues {
+		return nil, fmt.Errorf("numeral: at most %d digit values are supported by a counter, got: %d", maxAtomicValues, len(values))
+	}
+	if width < 1 || width > maxAtomicWidth {
+		return nil, fmt.Errorf("numeral: invalid counter width %d, must be between 1 and %d", width, maxAtomicWidth)
+	}
+	return new(big.Int).Exp(big.NewInt(int64(len(values))), big.NewInt(int64(width)), nil), nil
+}
+
+// appendFixed appends v as width digits of values to dst, without allocating
+// when dst has room. When v has more digits than width, dst is returned
+// unchanged with an error. When all the digit values are ASCII the digits are
+// written in place, otherwise they are collected first and then UTF-8
+// encoded.
+func appendFixed(dst []byte, values []rune, ascii bool, width int, v Uint128) ([]byte, error) {
+	u := v
+	var indexes [maxAtomicWidth]uint16
+	start := len(dst)
+	if ascii {
+		for i := 0; i < width; i++ {
+			dst = append(dst, 0)
+		}
+	}
+	put := func(i int, d uint64) {
+		if ascii {
+			dst[start+i] = byte(values[d])
+		} else {
+			indexes[i] = uint16(d)
+		}
+	}
+	base := uint64(len(values))
+	i := width - 1
+	// 128 bit division is only needed while the high half is set.
+	for ; i >= 0 && v.Hi != 0; i-- {
+		var r uint64
+		v.Hi, r = v.Hi/base, v.Hi%base
+		v.Lo, r = bits.Div64(r, v.Lo, base)
+		put(i, r)
+	}
+	for ; i >= 0; i-- {
+		put(i, v.Lo%base)
+		v.Lo /= base
+	}
+	if v.Hi != 0 || v.Lo != 0 {
+		return dst[:start], fmt.Errorf("numeral: %s does not fit in %d digits", u.String(), width)
+	}
+	if !ascii {
+		for _, d := range indexes[:width] {
+			dst = appendRune(dst, values[d])
+		}
+	}
+	return dst, nil
+}
+
+// isASCII reports whether all the digit values are ASCII characters.
+func isASCII(values []rune) bool {
+	for _, r := range values {
+		if r >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package numeral_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestAtomicCounter(t *testing.T) {
+	c, err := numeral.NewAtomicCounter(hexValues, 2, 0xfd)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	for _, want := range []string{"fd", "fe", "ff"} {
+		got, err := c.NextString()
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if got != want {
+			t.Errorf("got: %s want: %s", got, want)
+		}
+	}
+	if _, err := c.Next(); err != numeral.ErrOverflow {
+		t.Errorf("got: %v want: %v", err, numeral.ErrOverflow)
+	}
+	if c.Peek() != 0x100 {
+		t.Errorf("got: %d want the counter to stay at %d", c.Peek(), 0x100)
+	}
+}
+
+func TestAtomicCounterAppend(t *testing.T) {
+	c, _ := numeral.NewAtomicCounter([]rune("αβγδ"), 5, 0)
+	appendTests := []struct {
+		v    uint64
+		want string
+	}{
+		{0, "ααααα"},
+		{27, "ααβγδ"},
+		{1023, "δδδδδ"},
+	}
+	for _, tt := range appendTests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := c.Append(nil, tt.v)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAtomicCounterAppendDoesNotFitThrowsErr(t *testing.T) {
+	c, _ := numeral.NewAtomicCounter(hexValues, 2, 0)
+	dst := []byte("id:")
+	if got, err := c.Append(dst, 0x100); err == nil || string(got) != "id:" {
+		t.Errorf("got: %q, %v want: id: and an error", got, err)
+	}
+	if got, err := c.AppendUint128(dst, numeral.Uint128{Hi: 1}); err == nil || string(got) != "id:" {
+		t.Errorf("got: %q, %v want: id: and an error", got, err)
+	}
+	wide, _ := numeral.NewAtomicCounter(hexValues, 20, 0)
+	got, err := wide.AppendUint128(nil, numeral.Uint128{Hi: 1, Lo: 2})
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if want := "00010000000000000002"; string(got) != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+	if _, err := wide.AppendUint128(nil, numeral.Uint128{Hi: 1 << 16}); err == nil {
+		t.Error("expected error to be thrown on a value of 21 digits")
+	}
+}
+
+func TestAtomicCounterAppendNextDoesNotAllocate(t *testing.T) {
+	c, _ := numeral.NewAtomicCounter(testValues, 13, 0)
+	buf := make([]byte, 0, 13)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = c.AppendNext(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("got: %v allocations want: 0", allocs)
+	}
+}
+
+func TestAtomicCounterConcurrent(t *testing.T) {
+	c, _ := numeral.NewAtomicCounter(testValues, 4, 0)
+	const workers, perWorker = 8, 1000
+	var mu sync.Mutex
+	seen := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got := make([]uint64, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				v, err := c.Next()
+				if err != nil {
+					t.Errorf("expected nil got err: %v", err)
+					return
+				}
+				got = append(got, v)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, v := range got {
+				if seen[v] {
+					t.Errorf("got: %d twice", v)
+				}
+				seen[v] = true
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != workers*perWorker {
+		t.Errorf("got: %d values want: %d", len(seen), workers*perWorker)
+	}
+}
+
+func TestAtomicCounter128(t *testing.T) {
+	start := numeral.Uint128{Hi: 0, Lo: 1<<64 - 1}
+	c, err := numeral.NewAtomicCounter128(hexValues, 32, start)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	for _, want := range []string{"0000000000000000ffffffffffffffff", "00000000000000010000000000000000"} {
+		got, err := c.NextString()
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if got != want {
+			t.Errorf("got: %s want: %s", got, want)
+		}
+	}
+	if got, want := c.Peek().String(), "18446744073709551617"; got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+
+	small, _ := numeral.NewAtomicCounter128(decimalValues, 20, numeral.Uint128{Lo: 99999999999999999999 % (1 << 64), Hi: 99999999999999999999 >> 64})
+	if _, err := small.Next(); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if _, err := small.Next(); err != numeral.ErrOverflow {
+		t.Errorf("got: %v want: %v", err, numeral.ErrOverflow)
+	}
+}
+
+func TestAtomicCounter128AppendNextDoesNotAllocate(t *testing.T) {
+	c, _ := numeral.NewAtomicCounter128(testValues, 25, numeral.Uint128{Lo: 1<<64 - 50})
+	buf := make([]byte, 0, 25)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = c.AppendNext(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("got: %v allocations want: 0", allocs)
+	}
+}
+
+func TestAtomicCounter128Concurrent(t *testing.T) {
+	// the values cross a carry into the high word.
+	const workers, perWorker = 8, 1000
+	start := numeral.Uint128{Hi: 7, Lo: 1<<64 - workers*perWorker/2}
+	c, _ := numeral.NewAtomicCounter128(hexValues, 32, start)
+	var mu sync.Mutex
+	seen := make(map[numeral.Uint128]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got := make([]numeral.Uint128, 0, perWorker)
+			for i := 0; i < perWorker; i++ {
+				v, err := c.Next()
+				if err != nil {
+					t.Errorf("expected nil got err: %v", err)
+					return
+				}
+				got = append(got, v)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, v := range got {
+				if seen[v] || (v.Hi != 7 && v.Hi != 8) {
+					t.Errorf("got: %v twice or out of range", v)
+				}
+				seen[v] = true
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != workers*perWorker {
+		t.Errorf("got: %d values want: %d", len(seen), workers*perWorker)
+	}
+	if want := (numeral.Uint128{Hi: 8, Lo: workers * perWorker / 2}); c.Peek() != want {
+		t.Errorf("got: %v want: %v", c.Peek(), want)
+	}
+}
+
+func TestNewAtomicCounterInvalid(t *testing.T) {
+	if _, err := numeral.NewAtomicCounter(decimalValues, 2, 100); err == nil {
+		t.Error("expected error to be thrown on a start that does not fit")
+	}
+	if _, err := numeral.NewAtomicCounter(decimalValues, 0, 0); err == nil {
+		t.Error("expected error to be thrown on zero width")
+	}
+	if _, err := numeral.NewAtomicCounter([]rune("0"), 4, 0); err == nil {
+		t.Error("expected error to be thrown on a single digit value")
+	}
+	many := make([]rune, 1<<16+1)
+	for i := range many {
+		many[i] = rune(0x10000 + i)
+	}
+	if _, err := numeral.NewAtomicCounter(many, 1, 0); err == nil {
+		t.Error("expected error to be thrown on more than 65536 digit values")
+	}
+	if _, err := numeral.NewAtomicCounter128(decimalValues, 2, numeral.Uint128{Hi: 1}); err == nil {
+		t.Error("expected error to be thrown on a start that does not fit")
+	}
+}
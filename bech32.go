@@ -0,0 +1,305 @@
+package numeral
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Bech32Values are the digit values of the Bech32 alphabet (BIP-173).
+var Bech32Values = []rune("qpzry9x8gf2tvdw0s3jn54khce6mua7l")
+
+// Bech32Variant selects the constant the Bech32 checksum is built with.
+type Bech32Variant uint32
+
+const (
+	// Bech32 is the original checksum of BIP-173.
+	Bech32 Bech32Variant = 1
+	// Bech32m is the amended checksum of BIP-350.
+	Bech32m Bech32Variant = 0x2bc830a3
+)
+
+// bech32Separator separates the human readable part from the data part.
+const bech32Separator = '1'
+
+// bech32ChecksumLength is the amount of checksum digits of the data part.
+const bech32ChecksumLength = 6
+
+// bech32MaxLength is the maximum length of a Bech32 string.
+const bech32MaxLength = 90
+
+// bech32Generator holds the coefficients of the BCH generator polynomial.
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Bech32ChecksumError is returned when a Bech32 string is well formed but its
+// checksum does not match. Positions holds the indexes of the digits of the
+// string that are most likely wrong, when up to two digits are wrong and
+// they can be located. It unwraps to ErrChecksum.
+type Bech32ChecksumError struct {
+	Positions []int
+}
+
+// Error implements the error interface.
+func (e *Bech32ChecksumError) Error() string {
+	if len(e.Positions) == 0 {
+		return ErrChecksum.Error()
+	}
+	return fmt.Sprintf("%s, likely errors at positions: %v", ErrChecksum.Error(), e.Positions)
+}
+
+// Unwrap returns ErrChecksum.
+func (e *Bech32ChecksumError) Unwrap() error {
+	return ErrChecksum
+}
+
+// Bech32Encode encodes a human readable part and data made of 5 bit groups
+// with a Bech32 or Bech32m checksum, using 32 digit values. Use ConvertBits
+// to regroup bytes into 5 bit groups. The human readable part is written in
+// lower case. When the values contain the separator 1, as the Crockford ones
+// do, the human readable part must not contain it.
+func Bech32Encode(values []rune, hrp string, data []byte, variant Bech32Variant) (string, error) {
+	if len(values) != 32 {
+		return "", fmt.Errorf("numeral: Bech32 needs 32 digit values, got: %d", len(values))
+	}
+	if err := validateHRP(hrp); err != nil {
+		return "", err
+	}
+	if hrp != strings.ToLower(hrp) && hrp != strings.ToUpper(hrp) {
+		return "", fmt.Errorf("numeral: mixed case human readable part: %s", hrp)
+	}
+	if indexOf(bech32Separator, values) != -1 && strings.ContainsRune(hrp, bech32Separator) {
+		return "", fmt.Errorf("numeral: human readable part can not contain %q when the digit values do: %s", bech32Separator, hrp)
+	}
+	hrp = strings.ToLower(hrp)
+	for i, d := range data {
+		if d >= 32 {
+			return "", fmt.Errorf("numeral: invalid 5 bit group %d at position %d", d, i)
+		}
+	}
+	if len(hrp)+1+len(data)+bech32ChecksumLength > bech32MaxLength {
+		return "", fmt.Errorf("numeral: Bech32 string longer than %d characters", bech32MaxLength)
+	}
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteRune(bech32Separator)
+	for _, d := range data {
+		b.WriteRune(values[d])
+	}
+	for _, d := range bech32Checksum(hrp, data, variant) {
+		b.WriteRune(values[d])
+	}
+	return b.String(), nil
+}
+
+// Bech32Decode decodes a Bech32 or Bech32m string using 32 digit values. It
+// returns the human readable part in lower case, the data as 5 bit groups
+// without the checksum and the variant of the checksum. When none of the
+// values is an upper case letter, as with the Bech32 alphabet, the string is
+// read case insensitively and strings that mix upper and lower case are
+// rejected; otherwise the data part must match the values exactly. Positions
+// in errors count characters, not bytes. When the checksum does not match it
+// returns a *Bech32ChecksumError.
+func Bech32Decode(values []rune, s string) (hrp string, data []byte, variant Bech32Variant, err error) {
+	if len(values) != 32 {
+		return "", nil, 0, fmt.Errorf("numeral: Bech32 needs 32 digit values, got: %d", len(values))
+	}
+	chars := []rune(s)
+	if len(chars) > bech32MaxLength {
+		return "", nil, 0, fmt.Errorf("numeral: Bech32 string longer than %d characters", bech32MaxLength)
+	}
+	caseInsensitive := !hasUpper(values)
+	if caseInsensitive {
+		if s != strings.ToLower(s) && s != strings.ToUpper(s) {
+			return "", nil, 0, fmt.Errorf("numeral: mixed case Bech32 string: %s", s)
+		}
+		chars = []rune(strings.ToLower(s))
+	}
+	// the human readable part can contain the separator, unless the values
+	// do, in which case the data part can.
+	sep := -1
+	for i, c := range chars {
+		if c == bech32Separator {
+			sep = i
+			if indexOf(bech32Separator, values) != -1 {
+				break
+			}
+		}
+	}
+	if sep < 1 || sep+1+bech32ChecksumLength > len(chars) {
+		return "", nil, 0, fmt.Errorf("numeral: invalid separator position in: %s", s)
+	}
+	hrp = string(chars[:sep])
+	if err := validateHRP(hrp); err != nil {
+		return "", nil, 0, err
+	}
+	if !caseInsensitive {
+		if hrp != strings.ToLower(hrp) && hrp != strings.ToUpper(hrp) {
+			return "", nil, 0, fmt.Errorf("numeral: mixed case human readable part: %s", hrp)
+		}
+		hrp = strings.ToLower(hrp)
+	}
+	digits := make([]byte, 0, len(chars)-sep-1)
+	for i, c := range chars[sep+1:] {
+		d := indexOf(c, values)
+		if d == -1 {
+			return "", nil, 0, fmt.Errorf("numeral: invalid digit %q at position %d", c, sep+1+i)
+		}
+		digits = append(digits, byte(d))
+	}
+	residue := bech32Polymod(hrp, digits)
+	switch Bech32Variant(residue) {
+	case Bech32, Bech32m:
+		return hrp, digits[:len(digits)-bech32ChecksumLength], Bech32Variant(residue), nil
+	}
+	// report the smallest set of errors that fixes either of the variants.
+	var positions []int
+	for _, v := range []Bech32Variant{Bech32, Bech32m} {
+		if p := bech32Locate(hrp, digits, residue^uint32(v)); p != nil && (positions == nil || len(p) < len(positions)) {
+			positions = p
+		}
+	}
+	for i := range positions {
+		positions[i] += sep + 1
+	}
+	return "", nil, 0, &Bech32ChecksumError{Positions: positions}
+}
+
+// ConvertBits regroups data made of fromBits bit groups to toBits bit groups,
+// e.g. from bytes (8) to Bech32 digits (5). When pad is true the last group is
+// filled up with zero bits, otherwise any leftover bits must be zero.
+func ConvertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	if fromBits < 1 || fromBits > 8 || toBits < 1 || toBits > 8 {
+		return nil, fmt.Errorf("numeral: invalid bit group sizes: %d, %d", fromBits, toBits)
+	}
+	var acc uint32
+	var nbits uint
+	maxValue := uint32(1)<<toBits - 1
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for i, d := range data {
+		if d>>fromBits != 0 {
+			return nil, fmt.Errorf("numeral: invalid %d bit group %d at position %d", fromBits, d, i)
+		}
+		acc = acc<<fromBits | uint32(d)
+		nbits += fromBits
+		for nbits >= toBits {
+			nbits -= toBits
+			out = append(out, byte(acc>>nbits&maxValue))
+		}
+	}
+	if pad {
+		if nbits > 0 {
+			out = append(out, byte(acc<<(toBits-nbits)&maxValue))
+		}
+	} else if nbits >= fromBits || acc<<(toBits-nbits)&maxValue != 0 {
+		return nil, fmt.Errorf("numeral: invalid padding while regrouping bits")
+	}
+	return out, nil
+}
+
+// validateHRP checks that a human readable part is made of printable ASCII.
+func validateHRP(hrp string) error {
+	if len(hrp) == 0 {
+		return fmt.Errorf("numeral: empty human readable part")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return fmt.Errorf("numeral: invalid character %q at position %d of human readable part", hrp[i], i)
+		}
+	}
+	return nil
+}
+
+// hasUpper reports whether any of the values is an upper case letter.
+func hasUpper(values []rune) bool {
+	for _, v := range values {
+		if unicode.IsUpper(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// bech32Checksum returns the 6 checksum digits of a human readable part and
+// data.
+func bech32Checksum(hrp string, data []byte, variant Bech32Variant) []byte {
+	padded := make([]byte, len(data)+bech32ChecksumLength)
+	copy(padded, data)
+	residue := bech32Polymod(hrp, padded) ^ uint32(variant)
+	checksum := make([]byte, bech32ChecksumLength)
+	for i := range checksum {
+		checksum[i] = byte(residue >> (5 * uint(bech32ChecksumLength-1-i)) & 31)
+	}
+	return checksum
+}
+
+// bech32Polymod computes the BCH checksum residue of the expanded human
+// readable part followed by the data.
+func bech32Polymod(hrp string, data []byte) uint32 {
+	chk := uint32(1)
+	step := func(v byte) {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := uint(0); i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	for i := 0; i < len(hrp); i++ {
+		step(hrp[i] >> 5)
+	}
+	step(0)
+	for i := 0; i < len(hrp); i++ {
+		step(hrp[i] & 31)
+	}
+	for _, d := range data {
+		step(d)
+	}
+	return chk
+}
+
+// bech32Locate finds up to two digit positions of data whose change cancels
+// the syndrome, the difference between the residue and the expected one.
+// The residue is affine in the data, so the syndrome of an error is the XOR
+// of the syndromes of its single bit flips. It returns nil when the errors
+// can not be located.
+func bech32Locate(hrp string, data []byte, syndrome uint32) []int {
+	zero := bech32Polymod(hrp, make([]byte, len(data)))
+	// effect of every single digit error, keyed by its syndrome.
+	type digitError struct{ pos, delta int }
+	singles := make(map[uint32]digitError, len(data)*31)
+	effects := make([][32]uint32, len(data))
+	unit := make([]byte, len(data))
+	for p := range data {
+		var bitEffects [5]uint32
+		for b := uint(0); b < 5; b++ {
+			unit[p] = 1 << b
+			bitEffects[b] = bech32Polymod(hrp, unit) ^ zero
+		}
+		unit[p] = 0
+		for delta := 1; delta < 32; delta++ {
+			var effect uint32
+			for b := uint(0); b < 5; b++ {
+				if delta>>b&1 == 1 {
+					effect ^= bitEffects[b]
+				}
+			}
+			effects[p][delta] = effect
+			singles[effect] = digitError{p, delta}
+		}
+	}
+	if e, ok := singles[syndrome]; ok {
+		return []int{e.pos}
+	}
+	for p := range data {
+		for delta := 1; delta < 32; delta++ {
+			if e, ok := singles[syndrome^effects[p][delta]]; ok && e.pos != p {
+				positions := []int{p, e.pos}
+				sort.Ints(positions)
+				return positions
+			}
+		}
+	}
+	return nil
+}
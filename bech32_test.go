@@ -0,0 +1,199 @@
+package numeral_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestBech32DecodeValid(t *testing.T) {
+	validTests := []struct {
+		s       string
+		variant numeral.Bech32Variant
+	}{
+		{"A12UEL5L", numeral.Bech32},
+		{"a12uel5l", numeral.Bech32},
+		{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", numeral.Bech32},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", numeral.Bech32},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", numeral.Bech32},
+		{"?1ezyfcl", numeral.Bech32},
+		{"A1LQFN3A", numeral.Bech32m},
+		{"a1lqfn3a", numeral.Bech32m},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", numeral.Bech32m},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", numeral.Bech32m},
+		{"?1v759aa", numeral.Bech32m},
+	}
+	for _, tt := range validTests {
+		t.Run(tt.s, func(t *testing.T) {
+			hrp, data, variant, err := numeral.Bech32Decode(numeral.Bech32Values, tt.s)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if variant != tt.variant {
+				t.Errorf("variant got: %x want: %x", variant, tt.variant)
+			}
+			got, err := numeral.Bech32Encode(numeral.Bech32Values, hrp, data, variant)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != strings.ToLower(tt.s) {
+				t.Errorf("got: %s want: %s", got, strings.ToLower(tt.s))
+			}
+		})
+	}
+}
+
+func TestBech32DecodeInvalid(t *testing.T) {
+	invalidTests := []string{
+		"a12UEL5L",     // mixed case
+		"1nwldj5",      // empty human readable part
+		"pzry9x0s0muk", // no separator
+		"x1b4n0q5v",    // invalid digit
+		"li1dgmt3",     // checksum too short
+		"a12uel5m",     // wrong checksum
+		"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", // too long
+	}
+	for _, s := range invalidTests {
+		t.Run(s, func(t *testing.T) {
+			if _, _, _, err := numeral.Bech32Decode(numeral.Bech32Values, s); err == nil {
+				t.Errorf("expected error to be thrown on Bech32Decode")
+			}
+		})
+	}
+}
+
+func TestBech32DecodeSegwitAddress(t *testing.T) {
+	hrp, data, variant, err := numeral.Bech32Decode(numeral.Bech32Values, "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if hrp != "bc" || variant != numeral.Bech32 || data[0] != 0 {
+		t.Errorf("got: %s %x %d want: bc 1 0", hrp, variant, data[0])
+	}
+	program, err := numeral.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, want := hex.EncodeToString(program), "751e76e8199196d454941c45d1b3a323f1433bd6"; got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+}
+
+func TestBech32DecodeLocatesErrors(t *testing.T) {
+	valid := "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw"
+	locateTests := []struct {
+		positions []int
+	}{
+		{[]int{10}},
+		{[]int{7}},
+		{[]int{40}},
+		{[]int{12, 30}},
+	}
+	for _, tt := range locateTests {
+		t.Run("", func(t *testing.T) {
+			s := []byte(valid)
+			for _, p := range tt.positions {
+				// swap the digit with a different digit of the alphabet.
+				if s[p] == 'q' {
+					s[p] = 'p'
+				} else {
+					s[p] = 'q'
+				}
+			}
+			_, _, _, err := numeral.Bech32Decode(numeral.Bech32Values, string(s))
+			if !errors.Is(err, numeral.ErrChecksum) {
+				t.Fatalf("got: %v want: %v", err, numeral.ErrChecksum)
+			}
+			var checksumErr *numeral.Bech32ChecksumError
+			if !errors.As(err, &checksumErr) {
+				t.Fatalf("expected a *Bech32ChecksumError got: %T", err)
+			}
+			if !reflect.DeepEqual(checksumErr.Positions, tt.positions) {
+				t.Errorf("got: %v want: %v", checksumErr.Positions, tt.positions)
+			}
+		})
+	}
+}
+
+func TestBech32CustomAlphabet(t *testing.T) {
+	values := []rune("0123456789abcdefghjkmnpqrstvwxyz")
+	data, _ := numeral.ConvertBits([]byte("activation"), 8, 5, true)
+	s, err := numeral.Bech32Encode(values, "card", data, numeral.Bech32m)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	hrp, got, variant, err := numeral.Bech32Decode(values, strings.ToUpper(s))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if hrp != "card" || variant != numeral.Bech32m || !reflect.DeepEqual(got, data) {
+		t.Errorf("got: %s %x %v want: card %x %v", hrp, variant, got, numeral.Bech32m, data)
+	}
+}
+
+func TestBech32UpperCaseAlphabet(t *testing.T) {
+	data, _ := numeral.ConvertBits([]byte("activation"), 8, 5, true)
+	// the Crockford values are upper case and contain the separator.
+	data = append([]byte{1, 1}, data...)
+	s, err := numeral.Bech32Encode(numeral.CrockfordValues, "BC", data, numeral.Bech32)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if !strings.HasPrefix(s, "bc111") {
+		t.Errorf("got: %s want it to start with bc111", s)
+	}
+	hrp, got, variant, err := numeral.Bech32Decode(numeral.CrockfordValues, s)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if hrp != "bc" || variant != numeral.Bech32 || !reflect.DeepEqual(got, data) {
+		t.Errorf("got: %s %x %v want: bc %x %v", hrp, variant, got, numeral.Bech32, data)
+	}
+	if _, err := numeral.Bech32Encode(numeral.CrockfordValues, "a1b", data, numeral.Bech32); err == nil {
+		t.Error("expected error to be thrown on a separator in the human readable part")
+	}
+}
+
+func TestBech32NonASCIIAlphabet(t *testing.T) {
+	values := []rune("αβγδεζηθικλμνξοπρστυφχψωάέήίόύώ0")
+	data := []byte{0, 1, 2, 31, 30}
+	s, err := numeral.Bech32Encode(values, "gr", data, numeral.Bech32m)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	hrp, got, _, err := numeral.Bech32Decode(values, s)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if hrp != "gr" || !reflect.DeepEqual(got, data) {
+		t.Errorf("got: %s %v want: gr %v", hrp, got, data)
+	}
+	chars := []rune(s)
+	chars[5] = 'x'
+	if _, _, _, err := numeral.Bech32Decode(values, string(chars)); err == nil || !strings.Contains(err.Error(), "position 5") {
+		t.Errorf("got: %v want an invalid digit at position 5", err)
+	}
+	chars = []rune(s)
+	chars[4] = values[7]
+	var checksumErr *numeral.Bech32ChecksumError
+	if _, _, _, err := numeral.Bech32Decode(values, string(chars)); !errors.As(err, &checksumErr) || !reflect.DeepEqual(checksumErr.Positions, []int{4}) {
+		t.Errorf("got: %v want a checksum error at position 4", err)
+	}
+}
+
+func TestConvertBits(t *testing.T) {
+	five, err := numeral.ConvertBits([]byte{0xff}, 8, 5, true)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if want := []byte{31, 28}; !reflect.DeepEqual(five, want) {
+		t.Errorf("got: %v want: %v", five, want)
+	}
+	if _, err := numeral.ConvertBits([]byte{31, 29}, 5, 8, false); err == nil {
+		t.Errorf("expected error to be thrown on ConvertBits")
+	}
+}
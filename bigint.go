@@ -0,0 +1,20 @@
+package numeral
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigInt returns the value of the Numeral as an arbitrary precision integer.
+func (n *Numeral) BigInt() *big.Int {
+	return n.bigInt()
+}
+
+// NewFromBigInt creates a numeral in the system defined by values out of a
+// non negative arbitrary precision integer.
+func NewFromBigInt(values []rune, x *big.Int) (*Numeral, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed, got: %d", len(values))
+	}
+	return newFromBig(values, x)
+}
@@ -0,0 +1,22 @@
+package numeral_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestNewFromBigInt(t *testing.T) {
+	x, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	n, err := numeral.NewFromBigInt(testValues, x)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if n.BigInt().Cmp(x) != 0 {
+		t.Errorf("got: %v want: %v", n.BigInt(), x)
+	}
+	if _, err := numeral.NewFromBigInt(testValues, big.NewInt(-1)); err == nil {
+		t.Error("expected error to be thrown on a negative value")
+	}
+}
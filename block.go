@@ -0,0 +1,416 @@
+package numeral
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+)
+
+// blockPartial defines how a block codec handles a last block that is shorter
+// than a whole block.
+type blockPartial int
+
+const (
+	// partialNone rejects input that is not made of whole blocks.
+	partialNone blockPartial = iota
+	// partialTruncate fills up the block with zeros and keeps only the leading
+	// digits that are needed, as Ascii85 does.
+	partialTruncate
+	// partialInteger encodes the bytes of the block as a shorter numeral, as
+	// Base45 does.
+	partialInteger
+)
+
+// BlockCodec encodes blocks of bytes as fixed width numerals of a system. Every
+// block of bytes is read as a big endian integer and written with a fixed
+// amount of digits, which is how Ascii85, Z85 and Base45 work.
+type BlockCodec struct {
+	values      []rune
+	lookup      map[rune]int
+	blockBytes  int
+	blockDigits int
+	// lsdFirst writes the least significant digit of every block first.
+	lsdFirst bool
+	partial  blockPartial
+	// zero, when set, is written instead of a whole block of zero bytes.
+	zero      rune
+	prefix    string
+	suffix    string
+	skipSpace bool
+}
+
+// Ascii85Values are the digit values of Ascii85, the characters from '!' to 'u'.
+var Ascii85Values = []rune("!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstu")
+
+// Z85Values are the digit values of ZeroMQ's Z85.
+var Z85Values = []rune("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#")
+
+// Base45Values are the digit values of Base45 (RFC 9285).
+var Base45Values = []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:")
+
+// Ascii85 is the Adobe flavour of Ascii85: 4 bytes are written as 5 digits, a
+// block of zero bytes is shortened to 'z' and the output is framed with <~ ~>.
+// Whitespace is ignored while decoding.
+var Ascii85 = newBlockCodec(BlockCodec{
+	values:      Ascii85Values,
+	blockBytes:  4,
+	blockDigits: 5,
+	partial:     partialTruncate,
+	zero:        'z',
+	prefix:      "<~",
+	suffix:      "~>",
+	skipSpace:   true,
+})
+
+// Z85 is ZeroMQ's Z85 (RFC 32/Z85): 4 bytes are written as 5 digits and the
+// input must be made of whole blocks.
+var Z85 = newBlockCodec(BlockCodec{
+	values:      Z85Values,
+	blockBytes:  4,
+	blockDigits: 5,
+	partial:     partialNone,
+})
+
+// Base45 is Base45 (RFC 9285): 2 bytes are written as 3 digits, least
+// significant first, and a last single byte as 2 digits.
+var Base45 = newBlockCodec(BlockCodec{
+	values:      Base45Values,
+	blockBytes:  2,
+	blockDigits: 3,
+	lsdFirst:    true,
+	partial:     partialInteger,
+})
+
+// newBlockCodec builds the digit lookup of a block codec.
+func newBlockCodec(c BlockCodec) *BlockCodec {
+	c.lookup = make(map[rune]int, len(c.values))
+	for i, v := range c.values {
+		c.lookup[v] = i
+	}
+	return &c
+}
+
+// Values returns the digit values of the codec.
+func (c *BlockCodec) Values() []rune {
+	return c.values
+}
+
+// Encode encodes src.
+func (c *BlockCodec) Encode(src []byte) (string, error) {
+	var b bytes.Buffer
+	enc := c.NewEncoder(&b)
+	if _, err := enc.Write(src); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Decode decodes s back to the bytes it encodes.
+func (c *BlockCodec) Decode(s string) ([]byte, error) {
+	return ioutil.ReadAll(c.NewDecoder(strings.NewReader(s)))
+}
+
+// partialDigits returns the amount of digits needed for n bytes, the least d
+// for which base^d >= 256^n.
+func (c *BlockCodec) partialDigits(n int) int {
+	base := uint64(len(c.values))
+	limit := uint64(1) << (8 * uint(n))
+	d := 0
+	for p := uint64(1); p < limit; p *= base {
+		d++
+	}
+	return d
+}
+
+// digitsOf returns the digit positions of v as a width digit numeral, in the
+// order they are written.
+func (c *BlockCodec) digitsOf(v uint64, width int) []int {
+	base := uint64(len(c.values))
+	digits := make([]int, width)
+	for i := width - 1; i >= 0; i-- {
+		digits[i] = int(v % base)
+		v /= base
+	}
+	if c.lsdFirst {
+		for i, j := 0, width-1; i < j; i, j = i+1, j-1 {
+			digits[i], digits[j] = digits[j], digits[i]
+		}
+	}
+	return digits
+}
+
+// appendDigits appends the digits of the given positions.
+func (c *BlockCodec) appendDigits(dst []byte, digits []int) []byte {
+	for _, d := range digits {
+		dst = appendRune(dst, c.values[d])
+	}
+	return dst
+}
+
+// digitsValue returns the value of a numeral of digit positions.
+func (c *BlockCodec) digitsValue(digits []int) uint64 {
+	base := uint64(len(c.values))
+	v := uint64(0)
+	if c.lsdFirst {
+		for i := len(digits) - 1; i >= 0; i-- {
+			v = v*base + uint64(digits[i])
+		}
+		return v
+	}
+	for _, d := range digits {
+		v = v*base + uint64(d)
+	}
+	return v
+}
+
+// BlockEncoder encodes the bytes written to it block by block and writes the
+// digits to an underlying writer, keeping at most one block in memory. Close
+// must be called to flush the last block and the framing.
+type BlockEncoder struct {
+	c       *BlockCodec
+	w       io.Writer
+	block   []byte
+	buf     []byte
+	started bool
+	closed  bool
+	lastErr error
+}
+
+// NewEncoder creates a stream encoder that writes to w.
+func (c *BlockCodec) NewEncoder(w io.Writer) *BlockEncoder {
+	return &BlockEncoder{
+		c:     c,
+		w:     w,
+		block: make([]byte, 0, c.blockBytes),
+	}
+}
+
+// Write encodes p to the underlying writer.
+func (e *BlockEncoder) Write(p []byte) (int, error) {
+	if e.lastErr != nil {
+		return 0, e.lastErr
+	}
+	if e.closed {
+		return 0, fmt.Errorf("numeral: write to closed encoder")
+	}
+	e.buf = e.start(e.buf[:0])
+	for _, b := range p {
+		e.block = append(e.block, b)
+		if len(e.block) == e.c.blockBytes {
+			e.buf = e.appendBlock(e.buf)
+			e.block = e.block[:0]
+		}
+	}
+	if _, err := e.w.Write(e.buf); err != nil {
+		e.lastErr = err
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes the last block and the framing to the underlying writer. It
+// does not close the underlying writer.
+func (e *BlockEncoder) Close() error {
+	if e.lastErr != nil || e.closed {
+		return e.lastErr
+	}
+	e.closed = true
+	e.buf = e.start(e.buf[:0])
+	if n := len(e.block); n > 0 {
+		switch e.c.partial {
+		case partialNone:
+			e.lastErr = fmt.Errorf("numeral: input must be a multiple of %d bytes", e.c.blockBytes)
+			return e.lastErr
+		case partialTruncate:
+			// fill the block up with zeros and keep only the leading digits.
+			v := blockValue(append(e.block, make([]byte, e.c.blockBytes-n)...))
+			e.buf = e.c.appendDigits(e.buf, e.c.digitsOf(v, e.c.blockDigits)[:e.c.partialDigits(n)])
+		case partialInteger:
+			e.buf = e.c.appendDigits(e.buf, e.c.digitsOf(blockValue(e.block), e.c.partialDigits(n)))
+		}
+		e.block = e.block[:0]
+	}
+	e.buf = append(e.buf, e.c.suffix...)
+	if _, err := e.w.Write(e.buf); err != nil {
+		e.lastErr = err
+	}
+	return e.lastErr
+}
+
+// start writes the opening frame before the first digit.
+func (e *BlockEncoder) start(dst []byte) []byte {
+	if e.started {
+		return dst
+	}
+	e.started = true
+	return append(dst, e.c.prefix...)
+}
+
+// appendBlock appends the digits of a whole block.
+func (e *BlockEncoder) appendBlock(dst []byte) []byte {
+	v := blockValue(e.block)
+	if v == 0 && e.c.zero != 0 {
+		return appendRune(dst, e.c.zero)
+	}
+	return e.c.appendDigits(dst, e.c.digitsOf(v, e.c.blockDigits))
+}
+
+// blockValue reads a block of bytes as a big endian integer.
+func blockValue(block []byte) uint64 {
+	v := uint64(0)
+	for _, b := range block {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// BlockDecoder decodes digits read from an underlying reader back to bytes,
+// block by block.
+type BlockDecoder struct {
+	c       *BlockCodec
+	r       *bufio.Reader
+	digits  []int
+	pos     int
+	out     []byte
+	started bool
+	lastErr error
+}
+
+// NewDecoder creates a stream decoder that reads from r.
+func (c *BlockCodec) NewDecoder(r io.Reader) *BlockDecoder {
+	return &BlockDecoder{
+		c:      c,
+		r:      bufio.NewReader(r),
+		digits: make([]int, 0, c.blockDigits),
+	}
+}
+
+// Read decodes up to len(p) bytes into p.
+func (d *BlockDecoder) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(d.out) > 0 {
+			c := copy(p[n:], d.out)
+			d.out = d.out[c:]
+			n += c
+			continue
+		}
+		if d.lastErr != nil {
+			break
+		}
+		d.lastErr = d.fill(len(p) - n)
+	}
+	if n > 0 {
+		return n, nil
+	}
+	return 0, d.lastErr
+}
+
+// fill decodes digits until at least want bytes are available, the input is
+// over or an error occurs.
+func (d *BlockDecoder) fill(want int) error {
+	d.out = d.out[:0]
+	if !d.started {
+		d.started = true
+		// the opening frame is optional.
+		if p := d.c.prefix; p != "" {
+			if b, _ := d.r.Peek(len(p)); string(b) == p {
+				d.r.Discard(len(p))
+				d.pos += len(p)
+			}
+		}
+	}
+	for len(d.out) < want {
+		c, size, err := d.r.ReadRune()
+		if err == io.EOF {
+			if err := d.flush(); err != nil {
+				return err
+			}
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+		d.pos++
+		if d.c.skipSpace && unicode.IsSpace(c) {
+			continue
+		}
+		if s := d.c.suffix; s != "" && c == rune(s[0]) {
+			d.r.UnreadRune()
+			if b, _ := d.r.Peek(len(s)); string(b) == s {
+				if err := d.flush(); err != nil {
+					return err
+				}
+				return io.EOF
+			}
+			d.r.Discard(size)
+		}
+		if d.c.zero != 0 && c == d.c.zero {
+			if len(d.digits) != 0 {
+				return fmt.Errorf("numeral: zero block %q inside a block at position %d", c, d.pos-1)
+			}
+			d.out = append(d.out, make([]byte, d.c.blockBytes)...)
+			continue
+		}
+		i, ok := d.c.lookup[c]
+		if !ok {
+			return fmt.Errorf("numeral: invalid digit %q at position %d", c, d.pos-1)
+		}
+		d.digits = append(d.digits, i)
+		if len(d.digits) == d.c.blockDigits {
+			if err := d.appendBlock(d.digits, d.c.blockBytes); err != nil {
+				return err
+			}
+			d.digits = d.digits[:0]
+		}
+	}
+	return nil
+}
+
+// flush decodes the digits of a last block that is shorter than a whole block.
+func (d *BlockDecoder) flush() error {
+	m := len(d.digits)
+	if m == 0 {
+		return nil
+	}
+	n := 0
+	for n < d.c.blockBytes && d.c.partialDigits(n) != m {
+		n++
+	}
+	if d.c.partial == partialNone || n == 0 || n == d.c.blockBytes {
+		return fmt.Errorf("numeral: invalid amount of digits in last block: %d", m)
+	}
+	digits := d.digits
+	d.digits = d.digits[:0]
+	if d.c.partial == partialInteger {
+		return d.appendBlock(digits, n)
+	}
+	// fill the block up with the highest digit and keep only the leading bytes.
+	for len(digits) < d.c.blockDigits {
+		digits = append(digits, len(d.c.values)-1)
+	}
+	if err := d.appendBlock(digits, d.c.blockBytes); err != nil {
+		return err
+	}
+	d.out = d.out[:len(d.out)-(d.c.blockBytes-n)]
+	return nil
+}
+
+// appendBlock decodes a block of digits to n big endian bytes.
+func (d *BlockDecoder) appendBlock(digits []int, n int) error {
+	v := d.c.digitsValue(digits)
+	if v>>(8*uint(n)) != 0 {
+		return fmt.Errorf("numeral: block value %d does not fit in %d bytes at position %d", v, n, d.pos-1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		d.out = append(d.out, byte(v>>(8*uint(i))))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package numeral_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestBlockCodecs(t *testing.T) {
+	z85Hello, _ := hex.DecodeString("864fd26fb559f75b")
+	blockTests := []struct {
+		name  string
+		codec *numeral.BlockCodec
+		src   []byte
+		want  string
+	}{
+		{"ascii85", numeral.Ascii85, []byte("Man is distinguished"), "<~9jqo^BlbD-BleB1DJ+*+F(f,q~>"},
+		{"ascii85 zeros", numeral.Ascii85, []byte("\x00\x00\x00\x00abc\x00\x00\x00"), "<~z@:E^H!!!~>"},
+		{"ascii85 partial", numeral.Ascii85, []byte("hello world!"), "<~BOu!rD]j7BEbo80~>"},
+		{"ascii85 empty", numeral.Ascii85, []byte{}, "<~~>"},
+		{"z85", numeral.Z85, z85Hello, "HelloWorld"},
+		{"base45 AB", numeral.Base45, []byte("AB"), "BB8"},
+		{"base45 Hello!!", numeral.Base45, []byte("Hello!!"), "%69 VD92EX0"},
+		{"base45 base-45", numeral.Base45, []byte("base-45"), "UJCLQE7W581"},
+		{"base45 ietf!", numeral.Base45, []byte("ietf!"), "QED8WEX0"},
+	}
+	for _, tt := range blockTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.codec.Encode(tt.src)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+			decoded, err := tt.codec.Decode(tt.want)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if !bytes.Equal(decoded, tt.src) {
+				t.Errorf("decoded got: %q want: %q", decoded, tt.src)
+			}
+		})
+	}
+}
+
+func TestAscii85DecodeSkipsWhitespaceAndFraming(t *testing.T) {
+	got, err := numeral.Ascii85.Decode("9jqo^ BlbD-\nBleB1DJ+*+F(f,q")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if string(got) != "Man is distinguished" {
+		t.Errorf("got: %q want: %q", got, "Man is distinguished")
+	}
+}
+
+func TestBlockCodecInvalidInput(t *testing.T) {
+	invalidTests := []struct {
+		name  string
+		codec *numeral.BlockCodec
+		s     string
+	}{
+		{"z85 partial block", numeral.Z85, "Hell"},
+		{"z85 invalid digit", numeral.Z85, "Hello~orld"},
+		{"ascii85 overflow", numeral.Ascii85, "<~uuuuu~>"},
+		{"ascii85 z inside block", numeral.Ascii85, "<~9jz~>"},
+		{"base45 overflow", numeral.Base45, "GGW"},
+		{"base45 single digit", numeral.Base45, "BB8B"},
+	}
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.codec.Decode(tt.s); err == nil {
+				t.Errorf("expected error to be thrown on Decode")
+			}
+		})
+	}
+	if _, err := numeral.Z85.Encode([]byte("abc")); err == nil {
+		t.Errorf("expected error to be thrown on Encode")
+	}
+}
+
+func TestBlockCodecStreamRoundTrip(t *testing.T) {
+	src := make([]byte, 1<<18+3)
+	rand.New(rand.NewSource(1)).Read(src)
+	// leave some zero blocks for the Ascii85 shortcut.
+	copy(src[100:], make([]byte, 16))
+	for _, codec := range []*numeral.BlockCodec{numeral.Ascii85, numeral.Base45} {
+		var encoded bytes.Buffer
+		enc := codec.NewEncoder(&encoded)
+		if _, err := io.CopyBuffer(enc, bytes.NewReader(src), make([]byte, 1021)); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		decoded, err := ioutil.ReadAll(codec.NewDecoder(strings.NewReader(encoded.String())))
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if !bytes.Equal(decoded, src) {
+			t.Errorf("round trip failed for %s", string(codec.Values()[:4]))
+		}
+	}
+}
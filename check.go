@@ -0,0 +1,52 @@
+package numeral
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// checksumLength is the amount of checksum bytes appended to a payload.
+const checksumLength = 4
+
+// ErrChecksum is returned when a checksummed payload has been decoded but its
+// checksum does not match, usually because of a typo.
+var ErrChecksum = errors.New("numeral: checksum mismatch")
+
+// CheckEncode encodes a version byte and a payload followed by a 4 byte double
+// SHA-256 checksum, the way Base58Check does. Any system can carry a
+// checksummed payload, Base58Check is CheckEncode with Base58BitcoinValues.
+func CheckEncode(values []rune, version byte, payload []byte) (string, error) {
+	b := make([]byte, 0, 1+len(payload)+checksumLength)
+	b = append(b, version)
+	b = append(b, payload...)
+	sum := checksum(b)
+	b = append(b, sum[:]...)
+	return Encode(values, b)
+}
+
+// CheckDecode decodes a string produced by CheckEncode and verifies its
+// checksum. It returns ErrChecksum when the checksum does not match.
+func CheckDecode(values []rune, s string) (version byte, payload []byte, err error) {
+	b, err := Decode(values, s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) < 1+checksumLength {
+		return 0, nil, fmt.Errorf("numeral: checksummed payload too short: %d bytes", len(b))
+	}
+	data, sum := b[:len(b)-checksumLength], b[len(b)-checksumLength:]
+	if want := checksum(data); string(want[:]) != string(sum) {
+		return 0, nil, ErrChecksum
+	}
+	return data[0], data[1:], nil
+}
+
+// checksum returns the first bytes of the double SHA-256 of b.
+func checksum(b []byte) [checksumLength]byte {
+	h := sha256.Sum256(b)
+	h = sha256.Sum256(h[:])
+	var sum [checksumLength]byte
+	copy(sum[:], h[:checksumLength])
+	return sum
+}
@@ -0,0 +1,86 @@
+package numeral_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestCheckEncodeBase58(t *testing.T) {
+	checkTests := []struct {
+		version byte
+		payload string
+		want    string
+	}{
+		{20, "", "3MNQE1X"},
+		{20, " ", "B2Kr6dBE"},
+		{20, "-", "B3jv1Aft"},
+		{20, "0", "B482yuaX"},
+		{20, "1", "B4CmeGAC"},
+		{20, "-1", "mM7eUf6kB"},
+		{20, "11", "mP7BMTDVH"},
+		{20, "abc", "4QiVtDjUdeq"},
+		{20, "1234598760", "ZmNb8uQn5zvnUohNCEPP"},
+		{20, "abcdefghijklmnopqrstuvwxyz", "K2RYDcKfupxwXdWhSAxQPCeiULntKm63UXyx5MvEH2"},
+	}
+	for _, tt := range checkTests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := numeral.CheckEncode(numeral.Base58BitcoinValues, tt.version, []byte(tt.payload))
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+			version, payload, err := numeral.CheckDecode(numeral.Base58BitcoinValues, got)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if version != tt.version || string(payload) != tt.payload {
+				t.Errorf("decoded got: %d %q want: %d %q", version, payload, tt.version, tt.payload)
+			}
+		})
+	}
+}
+
+func TestCheckDecodeBitcoinAddress(t *testing.T) {
+	version, payload, err := numeral.CheckDecode(numeral.Base58BitcoinValues, "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	want, _ := hex.DecodeString("010966776006953d5567439e5e39f86a0d273bee")
+	if version != 0 || !bytes.Equal(payload, want) {
+		t.Errorf("got: %d %x want: 0 %x", version, payload, want)
+	}
+}
+
+func TestCheckDecodeBase62(t *testing.T) {
+	base62Values := []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	s, err := numeral.CheckEncode(base62Values, 7, []byte{0, 0, 42})
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	version, payload, err := numeral.CheckDecode(base62Values, s)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if version != 7 || !bytes.Equal(payload, []byte{0, 0, 42}) {
+		t.Errorf("got: %d %x want: 7 00002a", version, payload)
+	}
+}
+
+func TestCheckDecodeMismatchThrowsErrChecksum(t *testing.T) {
+	_, _, err := numeral.CheckDecode(numeral.Base58BitcoinValues, "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvN")
+	if err != numeral.ErrChecksum {
+		t.Errorf("got: %v want: %v", err, numeral.ErrChecksum)
+	}
+}
+
+func TestCheckDecodeTooShortThrowsErr(t *testing.T) {
+	_, _, err := numeral.CheckDecode(numeral.Base58BitcoinValues, "2g")
+	if err == nil || err == numeral.ErrChecksum {
+		t.Errorf("expected a format error to be thrown on CheckDecode, got: %v", err)
+	}
+}
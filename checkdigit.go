@@ -0,0 +1,276 @@
+package numeral
+
+import (
+	"container/ring"
+	"fmt"
+)
+
+// CrockfordValues are the digit values of Crockford's base32, which leaves out
+// I, L, O and U.
+var CrockfordValues = []rune("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+
+// CrockfordCheckValues are Crockford's base32 digit values followed by the 5
+// extra check symbols of its mod 37 check symbol. Numerals that carry a
+// CrockfordMod37 check symbol must use these values.
+var CrockfordCheckValues = []rune("0123456789ABCDEFGHJKMNPQRSTVWXYZ*~$=U")
+
+// CheckDigit computes and validates the trailing check symbols of a numeral.
+// The digits of a numeral are read as their positions in its digit values.
+type CheckDigit interface {
+	// Compute returns the check symbols of the digits of n.
+	Compute(n Numeral) (string, error)
+	// Validate reports whether n ends with valid check symbols.
+	Validate(n Numeral) bool
+}
+
+// AppendCheckDigit appends the check symbols computed by c to the Numeral.
+// The check symbols must be digit values of the Numeral.
+func (n *Numeral) AppendCheckDigit(c CheckDigit) error {
+	check, err := c.Compute(*n)
+	if err != nil {
+		return err
+	}
+	digits := make([]*ring.Ring, 0, len(check))
+	for _, r := range check {
+		d, err := newDigit(n.digitValues, r)
+		if err != nil {
+			return err
+		}
+		digits = append(digits, d)
+	}
+	for _, d := range digits {
+		n.digits.PushBack(d)
+	}
+	return nil
+}
+
+// VerifyCheckDigit reports whether the Numeral ends with valid check symbols.
+func (n *Numeral) VerifyCheckDigit(c CheckDigit) bool {
+	return c.Validate(*n)
+}
+
+// checkIndexes returns the digit positions of n, making sure that they are
+// all smaller than radix.
+func checkIndexes(n Numeral, radix int) ([]int, error) {
+	indexes := n.digitIndexes()
+	for i, d := range indexes {
+		if d >= radix {
+			return nil, fmt.Errorf("numeral: digit %q at position %d is not a radix %d digit", n.digitValues[d], i, radix)
+		}
+	}
+	return indexes, nil
+}
+
+// validateTrailing splits the last length digits of n off and compares them
+// with the check symbols computed over the rest.
+func validateTrailing(c CheckDigit, n Numeral, length int) bool {
+	if n.digits.Len() <= length {
+		return false
+	}
+	s := []rune(n.String())
+	data := newFromIndexes(n.digitValues, n.digitIndexes()[:len(s)-length])
+	check, err := c.Compute(*data)
+	if err != nil {
+		return false
+	}
+	return check == string(s[len(s)-length:])
+}
+
+// LuhnModN is the Luhn mod N algorithm, which works with any amount of digit
+// values and appends a single check digit of the same system. With 10 digit
+// values it is the Luhn algorithm of credit card numbers.
+type LuhnModN struct{}
+
+// Compute returns the check digit of n.
+func (LuhnModN) Compute(n Numeral) (string, error) {
+	base := len(n.digitValues)
+	indexes := n.digitIndexes()
+	factor := 2
+	sum := 0
+	// starting from the right, double every other digit.
+	for i := len(indexes) - 1; i >= 0; i-- {
+		addend := factor * indexes[i]
+		sum += addend/base + addend%base
+		factor = 3 - factor
+	}
+	return string(n.digitValues[(base-sum%base)%base]), nil
+}
+
+// Validate reports whether n ends with a valid check digit.
+func (c LuhnModN) Validate(n Numeral) bool {
+	return validateTrailing(c, n, 1)
+}
+
+// dammTable is the totally anti-symmetric quasigroup of order 10 used by the
+// Damm algorithm.
+var dammTable = [10][10]int{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// Damm is the Damm algorithm over decimal digits. It detects all single digit
+// errors and all adjacent transpositions.
+type Damm struct{}
+
+// Compute returns the check digit of n.
+func (Damm) Compute(n Numeral) (string, error) {
+	indexes, err := checkIndexes(n, 10)
+	if err != nil {
+		return "", err
+	}
+	interim := 0
+	for _, d := range indexes {
+		interim = dammTable[interim][d]
+	}
+	return string(n.digitValues[interim]), nil
+}
+
+// Validate reports whether n ends with a valid check digit.
+func (c Damm) Validate(n Numeral) bool {
+	return validateTrailing(c, n, 1)
+}
+
+// verhoeffMultiplication is the multiplication table of the dihedral group D5.
+var verhoeffMultiplication = [10][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+	{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+	{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+	{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+	{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+	{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+	{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+	{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+	{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+}
+
+// verhoeffPermutation is the permutation table of the Verhoeff algorithm.
+var verhoeffPermutation = [8][10]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+	{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+	{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+	{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+	{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+	{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+	{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+}
+
+// verhoeffInverse is the inverse table of the Verhoeff algorithm.
+var verhoeffInverse = [10]int{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
+
+// Verhoeff is the Verhoeff algorithm over decimal digits. It detects all
+// single digit errors and all adjacent transpositions.
+type Verhoeff struct{}
+
+// Compute returns the check digit of n.
+func (Verhoeff) Compute(n Numeral) (string, error) {
+	indexes, err := checkIndexes(n, 10)
+	if err != nil {
+		return "", err
+	}
+	c := 0
+	for i := 0; i < len(indexes); i++ {
+		d := indexes[len(indexes)-1-i]
+		c = verhoeffMultiplication[c][verhoeffPermutation[(i+1)%8][d]]
+	}
+	return string(n.digitValues[verhoeffInverse[c]]), nil
+}
+
+// Validate reports whether n ends with a valid check digit.
+func (c Verhoeff) Validate(n Numeral) bool {
+	return validateTrailing(c, n, 1)
+}
+
+// ISO7064 is a pure system of ISO/IEC 7064. The digits of a numeral are read
+// in Radix and the check symbols are chosen so that the whole numeral is
+// congruent to 1 modulo Modulus.
+type ISO7064 struct {
+	Modulus int
+	Radix   int
+	// CheckLength is the amount of check symbols, 1 or 2.
+	CheckLength int
+	// CheckValues are the symbols of the check values. With a single check
+	// symbol there must be Modulus of them, otherwise Radix.
+	CheckValues []rune
+}
+
+// The pure systems of ISO/IEC 7064.
+var (
+	// ISO7064Mod11Radix2 is MOD 11-2, over decimal digits with 'X' for 10.
+	ISO7064Mod11Radix2 = &ISO7064{Modulus: 11, Radix: 2, CheckLength: 1, CheckValues: []rune("0123456789X")}
+	// ISO7064Mod37Radix2 is MOD 37-2, over alphanumeric digits with '*' for 36.
+	ISO7064Mod37Radix2 = &ISO7064{Modulus: 37, Radix: 2, CheckLength: 1, CheckValues: []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ*")}
+	// ISO7064Mod97Radix10 is MOD 97-10, over decimal digits with two check
+	// digits, as used by IBAN.
+	ISO7064Mod97Radix10 = &ISO7064{Modulus: 97, Radix: 10, CheckLength: 2, CheckValues: []rune("0123456789")}
+)
+
+// Compute returns the check symbols of n.
+func (c *ISO7064) Compute(n Numeral) (string, error) {
+	// the data digits are read in the alphabet of the check values.
+	dataRadix := len(c.CheckValues)
+	if c.CheckLength == 1 {
+		dataRadix--
+	}
+	indexes, err := checkIndexes(n, dataRadix)
+	if err != nil {
+		return "", err
+	}
+	p := 0
+	for _, d := range indexes {
+		p = (p*c.Radix + d) % c.Modulus
+	}
+	shift := 1
+	for i := 0; i < c.CheckLength; i++ {
+		shift *= c.Radix
+	}
+	// the check value x makes p * radix^length + x congruent to 1.
+	x := ((1-p*shift)%c.Modulus + c.Modulus) % c.Modulus
+	if c.CheckLength == 1 {
+		return string(c.CheckValues[x]), nil
+	}
+	// more check symbols write x in radix.
+	check := make([]rune, c.CheckLength)
+	for i := c.CheckLength - 1; i >= 0; i-- {
+		check[i] = c.CheckValues[x%c.Radix]
+		x /= c.Radix
+	}
+	return string(check), nil
+}
+
+// Validate reports whether n ends with valid check symbols.
+func (c *ISO7064) Validate(n Numeral) bool {
+	return validateTrailing(c, n, c.CheckLength)
+}
+
+// CrockfordMod37 is the check symbol of Crockford's base32, the value of the
+// numeral modulo 37. Numerals must use CrockfordCheckValues so that the 5
+// extra check symbols can be appended.
+type CrockfordMod37 struct{}
+
+// Compute returns the check symbol of n.
+func (CrockfordMod37) Compute(n Numeral) (string, error) {
+	indexes, err := checkIndexes(n, len(CrockfordValues))
+	if err != nil {
+		return "", err
+	}
+	r := 0
+	for _, d := range indexes {
+		r = (r*len(CrockfordValues) + d) % 37
+	}
+	return string(CrockfordCheckValues[r]), nil
+}
+
+// Validate reports whether n ends with a valid check symbol.
+func (c CrockfordMod37) Validate(n Numeral) bool {
+	return validateTrailing(c, n, 1)
+}
@@ -0,0 +1,107 @@
+package numeral_test
+
+import (
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+var decimalValues = []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
+
+func TestCheckDigitCompute(t *testing.T) {
+	alphanumericValues := []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	computeTests := []struct {
+		name   string
+		check  numeral.CheckDigit
+		values []rune
+		number string
+		want   string
+	}{
+		{"luhn", numeral.LuhnModN{}, decimalValues, "7992739871", "3"},
+		{"luhn mod 36", numeral.LuhnModN{}, testValues, "a1b2", "9"},
+		{"damm", numeral.Damm{}, decimalValues, "572", "4"},
+		{"verhoeff", numeral.Verhoeff{}, decimalValues, "236", "3"},
+		{"verhoeff long", numeral.Verhoeff{}, decimalValues, "12345", "1"},
+		{"mod 11-2", numeral.ISO7064Mod11Radix2, decimalValues, "0794", "0"},
+		{"mod 11-2 X", numeral.ISO7064Mod11Radix2, decimalValues, "079", "X"},
+		{"mod 37-2", numeral.ISO7064Mod37Radix2, alphanumericValues, "G123498654321", "H"},
+		{"mod 97-10 iban", numeral.ISO7064Mod97Radix10, decimalValues, "32142829123456987654321611", "82"},
+		{"crockford", numeral.CrockfordMod37{}, numeral.CrockfordValues, "16J", "D"},
+	}
+	for _, tt := range computeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := numeral.NewNumeral(tt.values, tt.number)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			got, err := tt.check.Compute(*number)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendAndVerifyCheckDigit(t *testing.T) {
+	checkTests := []struct {
+		name   string
+		check  numeral.CheckDigit
+		values []rune
+		number string
+	}{
+		{"luhn", numeral.LuhnModN{}, testValues, "hello"},
+		{"damm", numeral.Damm{}, decimalValues, "1234567"},
+		{"verhoeff", numeral.Verhoeff{}, decimalValues, "1234567"},
+		{"mod 11-2", numeral.ISO7064Mod11Radix2, []rune("0123456789X"), "079"},
+		{"mod 97-10", numeral.ISO7064Mod97Radix10, decimalValues, "1234567"},
+		{"crockford", numeral.CrockfordMod37{}, numeral.CrockfordCheckValues, "ZZZZZZ"},
+	}
+	for _, tt := range checkTests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, _ := numeral.NewNumeral(tt.values, tt.number)
+			if err := number.AppendCheckDigit(tt.check); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if !number.VerifyCheckDigit(tt.check) {
+				t.Errorf("expected %s to have a valid check digit", number.String())
+			}
+			// a single digit typo must be detected.
+			s := []rune(number.String())
+			if s[0] == tt.values[1] {
+				s[0] = tt.values[2]
+			} else {
+				s[0] = tt.values[1]
+			}
+			typo, _ := numeral.NewNumeral(tt.values, string(s))
+			if typo.VerifyCheckDigit(tt.check) {
+				t.Errorf("expected %s to have an invalid check digit", typo.String())
+			}
+		})
+	}
+}
+
+func TestAppendCheckDigitOutsideValuesThrowsErr(t *testing.T) {
+	number, _ := numeral.NewNumeral(decimalValues, "079")
+	if err := number.AppendCheckDigit(numeral.ISO7064Mod11Radix2); err == nil {
+		t.Errorf("expected error to be thrown on AppendCheckDigit")
+	}
+	if number.String() != "079" {
+		t.Errorf("expected: 079, got: %s", number.String())
+	}
+}
+
+func TestIteratorFilterCheckDigit(t *testing.T) {
+	start, _ := numeral.NewNumeral(decimalValues, "100")
+	end, _ := numeral.NewNumeral(decimalValues, "199")
+	it, _ := numeral.NewIterator(*start, numeral.WithEnd(*end), numeral.WithFilter(numeral.Damm{}.Validate))
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 10 {
+		t.Errorf("got: %d codes want: 10", count)
+	}
+}
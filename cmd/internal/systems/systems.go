@@ -0,0 +1,156 @@
+// Package systems names the numeral systems and check digit algorithms of the
+// numeral package for the commands, which let users pick them by name.
+package systems
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/slysterous/numeral"
+)
+
+// systems are the digit values of well known numeral systems by name.
+var systems = map[string][]rune{
+	"bin":       []rune("01"),
+	"oct":       []rune("01234567"),
+	"dec":       []rune("0123456789"),
+	"hex":       []rune("0123456789abcdef"),
+	"b32":       []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"),
+	"crockford": numeral.CrockfordValues,
+	"b36":       []rune("0123456789abcdefghijklmnopqrstuvwxyz"),
+	"b45":       numeral.Base45Values,
+	"b57":       numeral.Base57Values,
+	"b58":       numeral.Base58BitcoinValues,
+	"b62":       numeral.Base62Values,
+	"bech32":    numeral.Bech32Values,
+	"ascii85":   numeral.Ascii85Values,
+	"z85":       numeral.Z85Values,
+}
+
+// Names returns the names of the systems in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(systems))
+	for name := range systems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Values returns the digit values of the system called name.
+func Values(name string) ([]rune, bool) {
+	values, ok := systems[name]
+	return values, ok
+}
+
+// Lookup returns the digit values of the system called s or, when there is no
+// such system, the digit values described by s as accepted by ParseValues.
+func Lookup(s string) ([]rune, error) {
+	if values, ok := systems[s]; ok {
+		return values, nil
+	}
+	return ParseValues(s)
+}
+
+// ParseValues parses a compact description of digit values, in which a-b
+// stands for every character from a to b, e.g. 0-9a-z for base36. A hyphen at
+// either end stands for itself. The digit values must be unique and at least
+// 2.
+func ParseValues(spec string) ([]rune, error) {
+	chars := []rune(spec)
+	var values []rune
+	seen := make(map[rune]bool)
+	add := func(r rune) error {
+		if seen[r] {
+			return fmt.Errorf("numeral: duplicate digit value %q in %q", r, spec)
+		}
+		seen[r] = true
+		values = append(values, r)
+		return nil
+	}
+	for i := 0; i < len(chars); i++ {
+		if i+2 < len(chars) && chars[i+1] == '-' {
+			from, to := chars[i], chars[i+2]
+			if from > to {
+				return nil, fmt.Errorf("numeral: invalid digit value range %c-%c in %q", from, to, spec)
+			}
+			for r := from; r <= to; r++ {
+				if err := add(r); err != nil {
+					return nil, err
+				}
+			}
+			i += 2
+			continue
+		}
+		if err := add(chars[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed, got: %q", spec)
+	}
+	return values, nil
+}
+
+// Parse creates a numeral of values out of s, which must not be empty.
+func Parse(values []rune, s string) (*numeral.Numeral, error) {
+	if s == "" {
+		return nil, errors.New("numeral: empty numeral")
+	}
+	return numeral.NewNumeral(values, s)
+}
+
+// Check is a check digit algorithm.
+type Check struct {
+	numeral.CheckDigit
+	// values are the digit values that numerals carrying the check symbols
+	// must use whatever their system, or nil when the system's ones do.
+	values []rune
+}
+
+// checks are the check digit algorithms by name.
+var checks = map[string]Check{
+	"luhn":          {CheckDigit: numeral.LuhnModN{}},
+	"damm":          {CheckDigit: numeral.Damm{}},
+	"verhoeff":      {CheckDigit: numeral.Verhoeff{}},
+	"crockford":     {CheckDigit: numeral.CrockfordMod37{}, values: numeral.CrockfordCheckValues},
+	"iso7064-11-2":  {CheckDigit: numeral.ISO7064Mod11Radix2},
+	"iso7064-37-2":  {CheckDigit: numeral.ISO7064Mod37Radix2},
+	"iso7064-97-10": {CheckDigit: numeral.ISO7064Mod97Radix10},
+}
+
+// CheckNames returns the names of the check digit algorithms in alphabetical
+// order.
+func CheckNames() []string {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupCheck returns the check digit algorithm called name, in any case.
+func LookupCheck(name string) (Check, error) {
+	c, ok := checks[strings.ToLower(name)]
+	if !ok {
+		return Check{}, fmt.Errorf("numeral: unknown check digit algorithm: %s", name)
+	}
+	return c, nil
+}
+
+// Validate reports whether s, a numeral of values, ends with valid check
+// symbols. Some algorithms, such as Crockford's, bring check symbols outside
+// of the system, so they read s with their own values instead.
+func (c Check) Validate(values []rune, s string) (bool, error) {
+	if c.values != nil {
+		values = c.values
+	}
+	n, err := Parse(values, s)
+	if err != nil {
+		return false, err
+	}
+	return n.VerifyCheckDigit(c.CheckDigit), nil
+}
@@ -0,0 +1,71 @@
+package systems
+
+import "testing"
+
+func TestParseValues(t *testing.T) {
+	parseTests := []struct {
+		spec string
+		want string
+	}{
+		{"0-9a-z", "0123456789abcdefghijklmnopqrstuvwxyz"},
+		{"01", "01"},
+		{"-0-3", "-0123"},
+		{"a-c-", "abc-"},
+		{"α-δ", "αβγδ"},
+	}
+	for _, tt := range parseTests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseValues(tt.spec)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got: %s want: %s", string(got), tt.want)
+			}
+		})
+	}
+	for _, spec := range []string{"", "0", "z-a", "0-9a-f0"} {
+		if _, err := ParseValues(spec); err == nil {
+			t.Errorf("expected error to be thrown on ParseValues(%q)", spec)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	hex, err := Lookup("hex")
+	if err != nil || string(hex) != "0123456789abcdef" {
+		t.Errorf("got: %s, %v want: 0123456789abcdef", string(hex), err)
+	}
+	spec, err := Lookup("0-7")
+	if err != nil || string(spec) != "01234567" {
+		t.Errorf("got: %s, %v want: 01234567", string(spec), err)
+	}
+	if _, err := Parse(hex, ""); err == nil {
+		t.Error("expected error to be thrown on an empty numeral")
+	}
+}
+
+func TestCheckValidate(t *testing.T) {
+	if _, err := LookupCheck("nope"); err == nil {
+		t.Error("expected error to be thrown on an unknown check digit algorithm")
+	}
+	luhn, err := LookupCheck("Luhn")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	dec, _ := Values("dec")
+	if valid, err := luhn.Validate(dec, "79927398713"); err != nil || !valid {
+		t.Errorf("got: %v, %v want: true", valid, err)
+	}
+	// the Crockford check symbols include 5 symbols outside of its system.
+	crockford, _ := LookupCheck("crockford")
+	values, _ := Values("crockford")
+	for _, s := range []string{"10*", "11~", "12$", "13=", "14U", "150"} {
+		if valid, err := crockford.Validate(values, s); err != nil || !valid {
+			t.Errorf("%s got: %v, %v want: true", s, valid, err)
+		}
+	}
+	if valid, _ := crockford.Validate(values, "10~"); valid {
+		t.Error("got: true want: false")
+	}
+}
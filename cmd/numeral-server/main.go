@@ -0,0 +1,77 @@
+// Command numeral-server exposes the numeral package over HTTP and JSON, so
+// that services written in other languages can share its numeral systems.
+//
+// Endpoints
+//
+//	POST /convert                {"value": "ff", "from": "hex", "to": "b36"}
+//	POST /calc                   {"op": "add", "operands": [{"value": "ff", "system": "hex"}, {"value": "10", "system": "dec"}], "to": "dec"}
+//	POST /validate               {"value": "79927398713", "system": "dec", "check": "luhn"}
+//	POST /counters/{name}/next   {"system": "b36"}
+//	GET  /range?from=0&to=ff&system=hex&step=1
+//
+// Systems are given by name, such as hex or b36, or as digit values such as
+// 0-9a-z. The range endpoint streams newline delimited JSON. The server shuts
+// down gracefully on SIGINT and SIGTERM, recording where every counter
+// stopped.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	dataDir := flag.String("data", ".", "directory of the counter files")
+	leaseSize := flag.Uint64("lease", 100, "counter values leased per file write")
+	maxBody := flag.Int64("max-body", 1<<20, "largest request body in bytes")
+	maxRange := flag.Int64("max-range", 100000, "largest amount of numerals a range streams")
+	maxInFlight := flag.Int("max-in-flight", 256, "largest amount of requests served at once")
+	flag.Parse()
+
+	s := newServer(config{
+		dataDir:      *dataDir,
+		leaseSize:    *leaseSize,
+		maxBodyBytes: *maxBody,
+		maxRange:     *maxRange,
+		maxInFlight:  *maxInFlight,
+	})
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      time.Minute,
+		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    1 << 16,
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", *addr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		log.Fatal(err)
+	case <-stop:
+	}
+	log.Print("shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+	if err := s.close(); err != nil {
+		log.Fatalf("closing counters: %v", err)
+	}
+}
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/slysterous/numeral"
+	"github.com/slysterous/numeral/cmd/internal/systems"
+	"github.com/slysterous/numeral/internal/atomicfile"
+)
+
+// config holds the settings of the server.
+type config struct {
+	// dataDir is the directory the counter files are kept in.
+	dataDir string
+	// leaseSize is the amount of counter values leased per file write.
+	leaseSize uint64
+	// maxBodyBytes is the largest request body accepted.
+	maxBodyBytes int64
+	// maxRange is the largest amount of numerals a range request streams.
+	maxRange int64
+	// maxInFlight is the largest amount of requests served at once.
+	maxInFlight int
+}
+
+// counterName restricts counter names to ones that are safe file names.
+var counterName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// server exposes the numeral package over HTTP and JSON.
+type server struct {
+	config   config
+	inFlight chan struct{}
+
+	mu       sync.Mutex
+	counters map[string]*namedCounter
+}
+
+// namedCounter is a persistent counter along with the digit values of its
+// system.
+type namedCounter struct {
+	values  string
+	counter *numeral.FileCounter
+}
+
+// newServer creates a server with the given settings.
+func newServer(c config) *server {
+	return &server{
+		config:   c,
+		inFlight: make(chan struct{}, c.maxInFlight),
+		counters: make(map[string]*namedCounter),
+	}
+}
+
+// handler returns the HTTP handler of all the endpoints.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/convert", s.post(s.convert))
+	mux.HandleFunc("/calc", s.post(s.calc))
+	mux.HandleFunc("/validate", s.post(s.validate))
+	mux.HandleFunc("/counters/", s.post(s.next))
+	mux.HandleFunc("/range", s.rangeNDJSON)
+	return s.limit(mux)
+}
+
+// close closes the counters, recording where each one stopped.
+func (s *server) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var first error
+	for name, c := range s.counters {
+		if err := c.counter.Close(); err != nil && first == nil {
+			first = err
+		}
+		delete(s.counters, name)
+	}
+	return first
+}
+
+// limit rejects requests beyond the in flight limit and caps request bodies.
+func (s *server) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s.inFlight <- struct{}{}:
+			defer func() { <-s.inFlight }()
+		default:
+			writeError(w, http.StatusServiceUnavailable, errors.New("too many requests in flight"))
+			return
+		}
+		source := &countingBody{ReadCloser: r.Body}
+		r.Body = &limitedBody{
+			ReadCloser: http.MaxBytesReader(w, source, s.config.maxBodyBytes),
+			source:     source,
+			limit:      s.config.maxBodyBytes,
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// countingBody counts the bytes read from a request body.
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+// limitedBody is a request body capped by http.MaxBytesReader. The reader
+// asks its source for a byte past the limit to tell that a body is too large,
+// so the bytes read from the source tell it too.
+type limitedBody struct {
+	io.ReadCloser
+	source *countingBody
+	limit  int64
+}
+
+// tooLarge reports whether the body went past the limit.
+func (b *limitedBody) tooLarge() bool {
+	return b.source.n > b.limit
+}
+
+// httpError is an error along with the status code it is answered with.
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *httpError) Unwrap() error {
+	return e.err
+}
+
+// badRequest wraps err into a 400 error.
+func badRequest(err error) error {
+	return &httpError{status: http.StatusBadRequest, err: err}
+}
+
+// post adapts a JSON endpoint that only accepts POST requests into a handler.
+func (s *server) post(fn func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		resp, err := fn(r)
+		if err != nil {
+			status := http.StatusInternalServerError
+			var he *httpError
+			if errors.As(err, &he) {
+				status = he.status
+			}
+			writeError(w, status, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// writeJSON answers with v as JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// errorResponse is the body of every failed request.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeError answers with err as JSON.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// decode reads the JSON body of r into v. An empty body is an error that
+// wraps io.EOF.
+func decode(r *http.Request, v interface{}) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if b, ok := r.Body.(*limitedBody); ok && b.tooLarge() {
+			return &httpError{status: http.StatusRequestEntityTooLarge, err: err}
+		}
+		return badRequest(fmt.Errorf("invalid request body: %w", err))
+	}
+	return nil
+}
+
+// parse reads value as a numeral of the system named or described by system.
+func parse(value, system string) (*numeral.Numeral, error) {
+	values, err := systems.Lookup(system)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	n, err := systems.Parse(values, value)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	return n, nil
+}
+
+// render writes x in the system named or described by system.
+func render(x *big.Int, system string) (string, error) {
+	values, err := systems.Lookup(system)
+	if err != nil {
+		return "", badRequest(err)
+	}
+	n, err := numeral.NewFromBigInt(values, x)
+	if err != nil {
+		return "", badRequest(err)
+	}
+	return n.String(), nil
+}
+
+// valueResponse is the body of the endpoints that answer with a numeral.
+type valueResponse struct {
+	Value string `json:"value"`
+}
+
+// convertRequest is the body of /convert.
+type convertRequest struct {
+	Value string `json:"value"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// convert writes a numeral of one system in another.
+func (s *server) convert(r *http.Request) (interface{}, error) {
+	var req convertRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	n, err := parse(req.Value, req.From)
+	if err != nil {
+		return nil, err
+	}
+	v, err := render(n.BigInt(), req.To)
+	if err != nil {
+		return nil, err
+	}
+	return valueResponse{Value: v}, nil
+}
+
+// operand is a numeral along with its system.
+type operand struct {
+	Value  string `json:"value"`
+	System string `json:"system"`
+}
+
+// calcRequest is the body of /calc.
+type calcRequest struct {
+	Op       string    `json:"op"`
+	Operands []operand `json:"operands"`
+	To       string    `json:"to"`
+}
+
+// calc applies an arithmetic operation to numerals of any systems, from left
+// to right.
+func (s *server) calc(r *http.Request) (interface{}, error) {
+	var req calcRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Operands) < 2 {
+		return nil, badRequest(fmt.Errorf("at least 2 operands are needed, got: %d", len(req.Operands)))
+	}
+	var result *big.Int
+	for i, o := range req.Operands {
+		n, err := parse(o.Value, o.System)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			result = n.BigInt()
+			continue
+		}
+		if result, err = apply(req.Op, result, n.BigInt()); err != nil {
+			return nil, badRequest(err)
+		}
+	}
+	v, err := render(result, req.To)
+	if err != nil {
+		return nil, err
+	}
+	return valueResponse{Value: v}, nil
+}
+
+// apply returns a op b. Numerals can not be negative, so neither can the
+// result.
+func apply(op string, a, b *big.Int) (*big.Int, error) {
+	z := new(big.Int)
+	switch op {
+	case "add", "+":
+		z.Add(a, b)
+	case "sub", "-":
+		z.Sub(a, b)
+		if z.Sign() < 0 {
+			return nil, fmt.Errorf("negative result: %v - %v", a, b)
+		}
+	case "mul", "*":
+		z.Mul(a, b)
+	case "div", "/", "mod", "%":
+		if b.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+		if op == "div" || op == "/" {
+			z.Quo(a, b)
+		} else {
+			z.Rem(a, b)
+		}
+	default:
+		return nil, fmt.Errorf("unknown operation: %s", op)
+	}
+	return z, nil
+}
+
+// validateRequest is the body of /validate.
+type validateRequest struct {
+	Value  string `json:"value"`
+	System string `json:"system"`
+	Check  string `json:"check"`
+}
+
+// validateResponse is the answer of /validate.
+type validateResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// validate checks the trailing check digits of a numeral.
+func (s *server) validate(r *http.Request) (interface{}, error) {
+	var req validateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	c, err := systems.LookupCheck(req.Check)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	values, err := systems.Lookup(req.System)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	valid, err := c.Validate(values, req.Value)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	return validateResponse{Valid: valid}, nil
+}
+
+// nextRequest is the optional body of /counters/{name}/next.
+type nextRequest struct {
+	System string `json:"system"`
+}
+
+// next issues the next value of a named persistent counter. Counters start
+// from zero in the system of their first request, dec by default, and keep
+// it across restarts; requests in another system are a conflict.
+func (s *server) next(r *http.Request) (interface{}, error) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/counters/"), "/")
+	if len(parts) != 2 || parts[1] != "next" {
+		return nil, &httpError{status: http.StatusNotFound, err: fmt.Errorf("not found: %s", r.URL.Path)}
+	}
+	name := parts[0]
+	if !counterName.MatchString(name) {
+		return nil, badRequest(fmt.Errorf("invalid counter name: %q", name))
+	}
+	// the length of a chunked body is unknown, so the body is read either way.
+	req := nextRequest{System: "dec"}
+	if err := decode(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	c, err := s.counter(name, req.System)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.Next()
+	if err != nil {
+		return nil, err
+	}
+	return valueResponse{Value: v}, nil
+}
+
+// counter returns the named counter, opening it on first use.
+func (s *server) counter(name, system string) (*numeral.FileCounter, error) {
+	values, err := systems.Lookup(system)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.counters[name]; ok {
+		if c.values != string(values) {
+			return nil, systemConflict(name, c.values)
+		}
+		return c.counter, nil
+	}
+	// the counter file holds digits only, so the system is kept next to it
+	// to read them back the same way after a restart.
+	path := filepath.Join(s.config.dataDir, name)
+	stored, err := ioutil.ReadFile(path + ".system")
+	switch {
+	case err == nil:
+		if string(stored) != string(values) {
+			return nil, systemConflict(name, string(stored))
+		}
+	case os.IsNotExist(err):
+		// the system is durable before the counter file can exist.
+		if err := atomicfile.WriteFile(path+".system", []byte(string(values))); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	zero, _ := numeral.NewFromBigInt(values, new(big.Int))
+	fc, err := numeral.OpenFileCounter(path+".counter", *zero, s.config.leaseSize)
+	if err != nil {
+		return nil, err
+	}
+	s.counters[name] = &namedCounter{values: string(values), counter: fc}
+	return fc, nil
+}
+
+// systemConflict is the error of a request for a counter in a system other
+// than its own.
+func systemConflict(name, values string) error {
+	return &httpError{status: http.StatusConflict, err: fmt.Errorf("counter %s uses the digit values %s", name, values)}
+}
+
+// rangeNDJSON streams the numerals from the from to the to query parameter,
+// both inclusive, as one JSON object per line. The step parameter is optional.
+func (s *server) rangeNDJSON(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	q := r.URL.Query()
+	system := q.Get("system")
+	from, err := parse(q.Get("from"), system)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	to, err := parse(q.Get("to"), system)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	step := big.NewInt(1)
+	if q.Get("step") != "" {
+		n, err := parse(q.Get("step"), system)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		step = n.BigInt()
+		if step.Sign() == 0 {
+			writeError(w, http.StatusBadRequest, errors.New("step can not be zero"))
+			return
+		}
+	}
+	count := new(big.Int).Sub(to.BigInt(), from.BigInt())
+	if count.Sign() < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("from %s is greater than to %s", from.String(), to.String()))
+		return
+	}
+	count.Quo(count, step)
+	if count.Cmp(big.NewInt(s.config.maxRange)) >= 0 {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("range has more than %d numerals", s.config.maxRange))
+		return
+	}
+	opts := []numeral.IteratorOption{numeral.WithEnd(*to)}
+	if step.Cmp(big.NewInt(1)) != 0 {
+		values, _ := systems.Lookup(system)
+		n, _ := numeral.NewFromBigInt(values, step)
+		opts = append(opts, numeral.WithStep(*n))
+	}
+	it, err := numeral.NewIterator(*from, opts...)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	for it.Next() {
+		if err := enc.Encode(valueResponse{Value: it.Numeral().String()}); err != nil {
+			// the client went away.
+			return
+		}
+	}
+}
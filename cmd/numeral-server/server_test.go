@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) (*server, *httptest.Server, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "numeral-server")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	s := newServer(config{
+		dataDir:      dir,
+		leaseSize:    10,
+		maxBodyBytes: 1024,
+		maxRange:     100,
+		maxInFlight:  8,
+	})
+	ts := httptest.NewServer(s.handler())
+	return s, ts, func() {
+		ts.Close()
+		s.close()
+		os.RemoveAll(dir)
+	}
+}
+
+func post(t *testing.T, url, body string) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	defer resp.Body.Close()
+	var got map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	return resp.StatusCode, got
+}
+
+func TestEndpoints(t *testing.T) {
+	_, ts, cleanup := newTestServer(t)
+	defer cleanup()
+	endpointTests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		key    string
+		want   interface{}
+	}{
+		{"convert", "/convert", `{"value":"ff","from":"hex","to":"dec"}`, 200, "value", "255"},
+		{"convert spec", "/convert", `{"value":"255","from":"dec","to":"0-9a-z"}`, 200, "value", "73"},
+		{"convert bad digit", "/convert", `{"value":"fg","from":"hex","to":"dec"}`, 400, "error", `numeral: invalid digit 'g' at position 1`},
+		{"calc", "/calc", `{"op":"add","operands":[{"value":"ff","system":"hex"},{"value":"1","system":"bin"}],"to":"dec"}`, 200, "value", "256"},
+		{"calc many", "/calc", `{"op":"*","operands":[{"value":"z","system":"b36"},{"value":"2","system":"dec"},{"value":"10","system":"dec"}],"to":"dec"}`, 200, "value", "700"},
+		{"calc negative", "/calc", `{"op":"sub","operands":[{"value":"1","system":"dec"},{"value":"2","system":"dec"}],"to":"dec"}`, 400, "", nil},
+		{"calc division by zero", "/calc", `{"op":"mod","operands":[{"value":"1","system":"dec"},{"value":"0","system":"dec"}],"to":"dec"}`, 400, "", nil},
+		{"validate", "/validate", `{"value":"79927398713","system":"dec","check":"luhn"}`, 200, "valid", true},
+		{"validate wrong", "/validate", `{"value":"79927398710","system":"dec","check":"luhn"}`, 200, "valid", false},
+		{"validate crockford", "/validate", `{"value":"10*","system":"crockford","check":"crockford"}`, 200, "valid", true},
+		{"validate unknown", "/validate", `{"value":"1","system":"dec","check":"nope"}`, 400, "", nil},
+		{"unknown field", "/convert", `{"value":"1","from":"dec","to":"dec","x":1}`, 400, "", nil},
+		{"too large", "/convert", `{"value":"` + strings.Repeat("1", 2048) + `","from":"dec","to":"dec"}`, 413, "", nil},
+	}
+	for _, tt := range endpointTests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, got := post(t, ts.URL+tt.path, tt.body)
+			if status != tt.status {
+				t.Errorf("got: %d want: %d (%v)", status, tt.status, got)
+			}
+			if tt.key != "" && got[tt.key] != tt.want {
+				t.Errorf("got: %v want: %v", got[tt.key], tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	_, ts, cleanup := newTestServer(t)
+	defer cleanup()
+	resp, err := http.Get(ts.URL + "/convert")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("got: %d want: %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestCounters(t *testing.T) {
+	s, ts, cleanup := newTestServer(t)
+	defer cleanup()
+	for _, want := range []string{"0", "1", "2"} {
+		_, got := post(t, ts.URL+"/counters/orders/next", `{"system":"b36"}`)
+		if got["value"] != want {
+			t.Errorf("got: %v want: %s", got["value"], want)
+		}
+	}
+	if status, _ := post(t, ts.URL+"/counters/orders/next", `{"system":"hex"}`); status != http.StatusConflict {
+		t.Errorf("got: %d want: %d", status, http.StatusConflict)
+	}
+	if status, _ := post(t, ts.URL+"/counters/bad.name/next", ``); status != http.StatusBadRequest {
+		t.Errorf("got: %d want: %d", status, http.StatusBadRequest)
+	}
+	// the counter resumes after a restart.
+	if err := s.close(); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if _, got := post(t, ts.URL+"/counters/orders/next", `{"system":"b36"}`); got["value"] != "3" {
+		t.Errorf("got: %v want: 3", got["value"])
+	}
+	if _, got := post(t, ts.URL+"/counters/invoices/next", ``); got["value"] != "0" {
+		t.Errorf("got: %v want: 0", got["value"])
+	}
+	// the system survives a restart too.
+	if err := s.close(); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if status, _ := post(t, ts.URL+"/counters/orders/next", `{"system":"dec"}`); status != http.StatusConflict {
+		t.Errorf("got: %d want: %d", status, http.StatusConflict)
+	}
+	if _, got := post(t, ts.URL+"/counters/orders/next", `{"system":"0-9a-z"}`); got["value"] != "4" {
+		t.Errorf("got: %v want: 4", got["value"])
+	}
+}
+
+func TestCounterChunkedBody(t *testing.T) {
+	s, _, cleanup := newTestServer(t)
+	defer cleanup()
+	h := s.handler()
+	chunkedTests := []struct {
+		body string
+		want string
+	}{
+		{``, "0"},
+		{`{"system":"dec"}`, "1"},
+		{" \n", "2"},
+	}
+	for _, tt := range chunkedTests {
+		t.Run(tt.body, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/counters/chunked/next", strings.NewReader(tt.body))
+			req.ContentLength = -1
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("got: %d want: %d (body: %s)", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			var got valueResponse
+			json.NewDecoder(rec.Body).Decode(&got)
+			if got.Value != tt.want {
+				t.Errorf("got: %s want: %s", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestTooLargeChunkedBody(t *testing.T) {
+	s, _, cleanup := newTestServer(t)
+	defer cleanup()
+	body := `{"system":"` + strings.Repeat("0", 2048) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/counters/chunked/next", strings.NewReader(body))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	s.handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("got: %d want: %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestRange(t *testing.T) {
+	_, ts, cleanup := newTestServer(t)
+	defer cleanup()
+	resp, err := http.Get(ts.URL + "/range?from=fd&to=103&system=hex&step=2")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("got: %s want: application/x-ndjson", ct)
+	}
+	var got []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var line valueResponse
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		got = append(got, line.Value)
+	}
+	if want := "fd ff 101 103"; strings.Join(got, " ") != want {
+		t.Errorf("got: %v want: %s", got, want)
+	}
+
+	resp, err = http.Get(ts.URL + "/range?from=0&to=1000&system=dec")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("got: %d want: %d", resp.StatusCode, http.StatusRequestEntityTooLarge)
+	}
+}
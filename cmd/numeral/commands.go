@@ -0,0 +1,287 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/slysterous/numeral"
+	"github.com/slysterous/numeral/cmd/internal/systems"
+)
+
+// result is the JSON form of the result of a single input.
+type result struct {
+	Input string `json:"input"`
+	Value string `json:"value,omitempty"`
+	Valid *bool  `json:"valid,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// fail reports the failure of a single input, on stderr and, in JSON mode, as
+// a result.
+func fail(p printer, stderr io.Writer, name, input string, err error) {
+	fmt.Fprintf(stderr, "numeral %s: %s: %v\n", name, input, err)
+	if p.json {
+		p.print("", result{Input: input, Error: err.Error()})
+	}
+}
+
+// convert writes numerals of one system in another.
+func convert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("convert", stderr)
+	from := fs.String("from", "dec", "system of the input numerals")
+	to := fs.String("to", "dec", "system of the output numerals")
+	asJSON := fs.Bool("json", false, "print JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	in, err := systems.Lookup(*from)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral convert: %v\n", err)
+		return exitUsage
+	}
+	out, err := systems.Lookup(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral convert: %v\n", err)
+		return exitUsage
+	}
+	p := printer{w: stdout, json: *asJSON}
+	status := exitOK
+	err = inputs(fs.Args(), stdin, func(s string) {
+		n, err := systems.Parse(in, s)
+		if err != nil {
+			fail(p, stderr, "convert", s, err)
+			status = exitFail
+			return
+		}
+		converted, _ := numeral.NewFromBigInt(out, n.BigInt())
+		p.print(converted.String(), result{Input: s, Value: converted.String()})
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral convert: %v\n", err)
+		return exitFail
+	}
+	return status
+}
+
+// calc evaluates arithmetic expressions over numerals of any systems.
+func calc(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("calc", stderr)
+	from := fs.String("from", "dec", "system of operands without a system prefix")
+	to := fs.String("to", "dec", "system of the results")
+	asJSON := fs.Bool("json", false, "print JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	in, err := systems.Lookup(*from)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral calc: %v\n", err)
+		return exitUsage
+	}
+	out, err := systems.Lookup(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral calc: %v\n", err)
+		return exitUsage
+	}
+	e := evaluator{input: in, system: systems.Values}
+	p := printer{w: stdout, json: *asJSON}
+	status := exitOK
+	expressions := fs.Args()
+	if len(expressions) > 0 {
+		// the arguments make up a single expression, e.g. numeral calc 1 + 2.
+		expressions = []string{strings.Join(expressions, " ")}
+	}
+	err = inputs(expressions, stdin, func(s string) {
+		v, err := evalTo(&e, s, out)
+		if err != nil {
+			fail(p, stderr, "calc", s, err)
+			if !p.json {
+				fmt.Fprint(stderr, caret(s, err))
+			}
+			status = exitFail
+			return
+		}
+		p.print(v, result{Input: s, Value: v})
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral calc: %v\n", err)
+		return exitFail
+	}
+	return status
+}
+
+// evalTo evaluates s and renders the result with out.
+func evalTo(e *evaluator, s string, out []rune) (string, error) {
+	x, err := e.eval(s)
+	if err != nil {
+		return "", err
+	}
+	if x.Sign() < 0 {
+		return "", fmt.Errorf("negative result %v can not be written as a numeral", x)
+	}
+	n, _ := numeral.NewFromBigInt(out, x)
+	return n.String(), nil
+}
+
+// caret returns s with a caret under the position of an expression error on
+// the next line, or nothing for other errors.
+func caret(s string, err error) string {
+	var ee *exprError
+	if !errors.As(err, &ee) {
+		return ""
+	}
+	return fmt.Sprintf("  %s\n  %s^\n", s, strings.Repeat(" ", ee.pos))
+}
+
+// seq prints a range of numerals like seq(1): the last one, the first and the
+// last one, or the first one, the step and the last one.
+func seq(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("seq", stderr)
+	sys := fs.String("sys", "dec", "system of the numerals")
+	equalWidth := fs.Bool("w", false, "pad the numerals with zeros to the width of the last one")
+	asJSON := fs.Bool("json", false, "print JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	values, err := systems.Lookup(*sys)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral seq: %v\n", err)
+		return exitUsage
+	}
+	one, _ := numeral.NewFromBigInt(values, big.NewInt(1))
+	var operands []string
+	switch rest := fs.Args(); len(rest) {
+	case 1:
+		operands = []string{one.String(), one.String(), rest[0]}
+	case 2:
+		operands = []string{rest[0], one.String(), rest[1]}
+	case 3:
+		operands = rest
+	default:
+		fmt.Fprintln(stderr, "numeral seq: expected [first [step]] last")
+		return exitUsage
+	}
+	var parsed [3]*numeral.Numeral
+	for i, s := range operands {
+		if parsed[i], err = systems.Parse(values, s); err != nil {
+			fmt.Fprintf(stderr, "numeral seq: %s: %v\n", s, err)
+			return exitUsage
+		}
+	}
+	first, step, last := parsed[0], parsed[1], parsed[2]
+	if step.BigInt().Sign() == 0 {
+		fmt.Fprintln(stderr, "numeral seq: step can not be zero")
+		return exitUsage
+	}
+	opts := []numeral.IteratorOption{numeral.WithEnd(*last)}
+	if step.BigInt().Cmp(big.NewInt(1)) != 0 {
+		opts = append(opts, numeral.WithStep(*step))
+	}
+	it, err := numeral.NewIterator(*first, opts...)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral seq: %v\n", err)
+		return exitUsage
+	}
+	width := len([]rune(last.String()))
+	p := printer{w: stdout, json: *asJSON}
+	for it.Next() {
+		s := it.Numeral().String()
+		if pad := width - len([]rune(s)); *equalWidth && pad > 0 {
+			s = strings.Repeat(string(values[0]), pad) + s
+		}
+		p.print(s, result{Value: s})
+	}
+	return exitOK
+}
+
+// count prints the size of the keyspace of numerals of some lengths.
+func count(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("count", stderr)
+	sys := fs.String("sys", "dec", "system of the numerals")
+	length := fs.Int("len", 0, "length of the numerals, sets both -min and -max")
+	min := fs.Int("min", 1, "shortest length of the numerals")
+	max := fs.Int("max", 0, "longest length of the numerals, -min by default")
+	to := fs.String("to", "dec", "system of the count")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *length > 0 {
+		*min, *max = *length, *length
+	}
+	if *max == 0 {
+		*max = *min
+	}
+	values, err := systems.Lookup(*sys)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral count: %v\n", err)
+		return exitUsage
+	}
+	out, err := systems.Lookup(*to)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral count: %v\n", err)
+		return exitUsage
+	}
+	if *min < 1 || *max < *min {
+		fmt.Fprintf(stderr, "numeral count: invalid lengths %d to %d\n", *min, *max)
+		return exitUsage
+	}
+	// every digit string of every length, leading zeros included.
+	total := new(big.Int)
+	base := big.NewInt(int64(len(values)))
+	for l := *min; l <= *max; l++ {
+		total.Add(total, new(big.Int).Exp(base, big.NewInt(int64(l)), nil))
+	}
+	n, _ := numeral.NewFromBigInt(out, total)
+	printer{w: stdout, json: *asJSON}.print(n.String(), struct {
+		Count string `json:"count"`
+	}{n.String()})
+	return exitOK
+}
+
+// validate checks the trailing check digits of numerals. It exits with a
+// failure when any of them is invalid.
+func validate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", stderr)
+	sys := fs.String("sys", "dec", "system of the numerals")
+	check := fs.String("check", "", "check digit algorithm: "+strings.Join(systems.CheckNames(), ", "))
+	asJSON := fs.Bool("json", false, "print JSON lines")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	c, err := systems.LookupCheck(*check)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral validate: %v\n", err)
+		return exitUsage
+	}
+	values, err := systems.Lookup(*sys)
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral validate: %v\n", err)
+		return exitUsage
+	}
+	p := printer{w: stdout, json: *asJSON}
+	status := exitOK
+	err = inputs(fs.Args(), stdin, func(s string) {
+		valid, err := c.Validate(values, s)
+		if err != nil {
+			fail(p, stderr, "validate", s, err)
+			status = exitFail
+			return
+		}
+		if !valid {
+			status = exitFail
+		}
+		text := "invalid"
+		if valid {
+			text = "valid"
+		}
+		p.print(s+"\t"+text, result{Input: s, Valid: &valid})
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "numeral validate: %v\n", err)
+		return exitFail
+	}
+	return status
+}
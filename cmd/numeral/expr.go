@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/slysterous/numeral"
+	"github.com/slysterous/numeral/cmd/internal/systems"
+)
+
+// exprOperators are the characters that end an operand.
+const exprOperators = "+-*/%()"
+
+// exprError is an error at a position of an expression, counted in
+// characters from zero.
+type exprError struct {
+	pos int
+	msg string
+}
+
+func (e *exprError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.msg, e.pos)
+}
+
+// evaluator evaluates arithmetic expressions over numerals of any systems.
+// Operands are numerals of the input system, or of another one when prefixed
+// with its name and a colon, e.g. hex:ff, and the operators are + - * / %
+// along with parentheses. Digits that are operators, spaces, colons or quotes
+// themselves, as in b45 or z85, are written between double quotes, e.g.
+// b45:"A+B", where a doubled quote stands for a quote. Division is integer
+// division.
+type evaluator struct {
+	// input is the system of operands without a prefix.
+	input []rune
+	// system looks up the digit values of a named system.
+	system func(name string) ([]rune, bool)
+	// variable looks up the value of a variable, if there are any.
+	variable func(name string) (*big.Int, bool)
+}
+
+// token is a lexical element of an expression.
+type token struct {
+	pos  int
+	text string
+	// positions are the positions of the characters of text in the
+	// expression, which differ from pos onwards once there are quotes.
+	positions []int
+	// quoted is the index of text where quoted characters start, or -1.
+	quoted int
+}
+
+// eval evaluates the expression s.
+func (e *evaluator) eval(s string) (*big.Int, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, &exprError{pos: 0, msg: "empty expression"}
+	}
+	p := parser{evaluator: e, tokens: tokens, end: len([]rune(s))}
+	x, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.i < len(tokens) {
+		return nil, &exprError{pos: tokens[p.i].pos, msg: fmt.Sprintf("unexpected %q", tokens[p.i].text)}
+	}
+	return x, nil
+}
+
+// tokenize splits s into operators and operands.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	chars := []rune(s)
+	for i := 0; i < len(chars); {
+		switch c := chars[i]; {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune(exprOperators, c):
+			tokens = append(tokens, token{pos: i, text: string(c), quoted: -1})
+			i++
+		default:
+			t := token{pos: i, quoted: -1}
+			var text []rune
+			for i < len(chars) && !unicode.IsSpace(chars[i]) && !strings.ContainsRune(exprOperators, chars[i]) {
+				if chars[i] != '"' {
+					text = append(text, chars[i])
+					t.positions = append(t.positions, i)
+					i++
+					continue
+				}
+				if t.quoted == -1 {
+					t.quoted = len(text)
+				}
+				// copy the quoted characters up to the closing quote.
+				open := i
+				for i++; ; i++ {
+					if i == len(chars) {
+						return nil, &exprError{pos: open, msg: "missing closing quote"}
+					}
+					if chars[i] == '"' {
+						if i+1 < len(chars) && chars[i+1] == '"' {
+							i++
+						} else {
+							break
+						}
+					}
+					text = append(text, chars[i])
+					t.positions = append(t.positions, i)
+				}
+				i++
+			}
+			t.text = string(text)
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser that evaluates as it goes.
+type parser struct {
+	*evaluator
+	tokens []token
+	i      int
+	end    int
+}
+
+// peek returns the text of the current token, or "" at the end.
+func (p *parser) peek() string {
+	if p.i < len(p.tokens) {
+		return p.tokens[p.i].text
+	}
+	return ""
+}
+
+// expr parses term (('+' | '-') term)*.
+func (p *parser) expr() (*big.Int, error) {
+	x, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.i++
+		y, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if op == "+" {
+			x.Add(x, y)
+		} else {
+			x.Sub(x, y)
+		}
+	}
+	return x, nil
+}
+
+// term parses factor (('*' | '/' | '%') factor)*.
+func (p *parser) term() (*big.Int, error) {
+	x, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "*" || op == "/" || op == "%"; op = p.peek() {
+		pos := p.tokens[p.i].pos
+		p.i++
+		y, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case op == "*":
+			x.Mul(x, y)
+		case y.Sign() == 0:
+			return nil, &exprError{pos: pos, msg: "division by zero"}
+		case op == "/":
+			x.Quo(x, y)
+		default:
+			x.Rem(x, y)
+		}
+	}
+	return x, nil
+}
+
+// factor parses '(' expr ')' or an operand.
+func (p *parser) factor() (*big.Int, error) {
+	if p.i >= len(p.tokens) {
+		return nil, &exprError{pos: p.end, msg: "unexpected end of expression"}
+	}
+	t := p.tokens[p.i]
+	p.i++
+	switch {
+	case t.text == "(":
+		x, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			pos := p.end
+			if p.i < len(p.tokens) {
+				pos = p.tokens[p.i].pos
+			}
+			return nil, &exprError{pos: pos, msg: "missing )"}
+		}
+		p.i++
+		return x, nil
+	case len(t.text) == 1 && strings.Contains(exprOperators, t.text):
+		return nil, &exprError{pos: t.pos, msg: fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return p.operand(t)
+}
+
+// operand evaluates a variable or a numeral, with an optional system prefix
+// before any quotes.
+func (p *parser) operand(t token) (*big.Int, error) {
+	if p.variable != nil && t.quoted == -1 {
+		if x, ok := p.variable(t.text); ok {
+			return new(big.Int).Set(x), nil
+		}
+	}
+	chars := []rune(t.text)
+	values, offset := p.input, 0
+	if i := indexRune(chars, ':'); i > 0 && (t.quoted == -1 || i < t.quoted) {
+		if v, ok := p.system(string(chars[:i])); ok {
+			values, offset = v, i+1
+		}
+	}
+	if offset == len(chars) {
+		return nil, &exprError{pos: t.pos, msg: fmt.Sprintf("missing digits after %q", t.text)}
+	}
+	n, err := systems.Parse(values, string(chars[offset:]))
+	if err != nil {
+		var de *numeral.DigitError
+		if errors.As(err, &de) {
+			return nil, &exprError{pos: t.positions[offset+de.Position], msg: fmt.Sprintf("invalid digit %q", de.Digit)}
+		}
+		return nil, &exprError{pos: t.pos, msg: err.Error()}
+	}
+	return n.BigInt(), nil
+}
+
+// indexRune returns the index of the first r in chars, or -1.
+func indexRune(chars []rune, r rune) int {
+	for i, c := range chars {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,127 @@
+// Command numeral works with numerals of custom positional systems from the
+// shell.
+//
+// Usage
+//
+//	numeral convert  [-from dec] [-to dec] [-json] [numeral ...]
+//	numeral calc     [-from dec] [-to dec] [-json] [expression ...]
+//	numeral seq      [-sys dec] [-w] [-json] [first [step]] last
+//	numeral count    [-sys dec] [-len n | -min n -max n] [-to dec] [-json]
+//	numeral validate [-sys dec] -check luhn [-json] [numeral ...]
+//	numeral repl     [-from dec] [-to dec] [-history file]
+//
+// Systems are given by name, such as hex, b36 or b58, or as digit values such
+// as 0-9a-z. Commands that take numerals or expressions read them one per line
+// from stdin when none are given as arguments. Expressions use + - * / % and
+// parentheses, and operands of other systems are prefixed with the name of
+// their system, e.g. hex:ff + b36:zz. Operands whose digits are operators,
+// spaces, colons or quotes, as in b45, ascii85 or z85, are written between
+// double quotes after the prefix, e.g. b45:"A+B", doubling any quote within
+// them. With -json every result is printed as a JSON object on its own line.
+//
+// The repl command is an interactive calculator. Besides expressions it takes
+// assignments such as x = hex:ff * 2, and commands such as :sys b36 0-9a-z to
+// define a system, :out b36 to choose the system of the results and :history
+// to list the history; :help lists them all.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// command runs a subcommand and returns its exit status.
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+// commands are the subcommands by name.
+var commands = map[string]command{
+	"convert":  convert,
+	"calc":     calc,
+	"seq":      seq,
+	"count":    count,
+	"validate": validate,
+	"repl":     repl,
+}
+
+// Exit statuses.
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the subcommand named by the first argument.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "numeral: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitUsage
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
+
+// usage prints the available subcommands.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "usage: numeral <command> [flags] [arguments]\n\ncommands: %s\n", strings.Join(names, ", "))
+	fmt.Fprintln(w, "run numeral <command> -h for the flags of a command")
+}
+
+// newFlagSet creates the flag set of a subcommand.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("numeral "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// inputs calls fn with every argument or, when there are none, with every non
+// empty line of stdin.
+func inputs(args []string, stdin io.Reader, fn func(string)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			fn(arg)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			fn(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// printer prints results either as text or as JSON lines.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// print prints text, or v as JSON.
+func (p printer) print(text string, v interface{}) {
+	if p.json {
+		json.NewEncoder(p.w).Encode(v)
+		return
+	}
+	fmt.Fprintln(p.w, text)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	runTests := []struct {
+		name   string
+		args   string
+		stdin  string
+		status int
+		stdout string
+		stderr string
+	}{
+		{"no command", "", "", exitUsage, "", "usage: numeral"},
+		{"unknown command", "frobnicate", "", exitUsage, "", `unknown command "frobnicate"`},
+		{"convert", "convert -from hex -to b36 ff 10", "", exitOK, "73\ng\n", ""},
+		{"convert spec", "convert -from 0-1 -to hex 11111111", "", exitOK, "ff\n", ""},
+		{"convert stdin", "convert -to bin", "5\n\n6\n", exitOK, "101\n110\n", ""},
+		{"convert json", "convert -from hex -json ff", "", exitOK, `{"input":"ff","value":"255"}` + "\n", ""},
+		{"convert bad digit", "convert -from hex ff fg 10", "", exitFail, "255\n16\n", "invalid digit 'g' at position 1"},
+		{"convert bad system", "convert -from z", "", exitUsage, "", "at least 2 digit values"},
+		{"calc", "calc -to hex hex:ff + 1", "", exitOK, "100\n", ""},
+		{"calc precedence", "calc 2 + 3 * (4 - 1) % 5", "", exitOK, "6\n", ""},
+		{"calc systems", "calc -from b36 -to dec z * bin:10", "", exitOK, "70\n", ""},
+		{"calc stdin json", "calc -json", "1+1\n7/2\n", exitOK, `{"input":"1+1","value":"2"}` + "\n" + `{"input":"7/2","value":"3"}` + "\n", ""},
+		{"calc bad digit", "calc 12 + hex:1g", "", exitFail, "", "  12 + hex:1g\n            ^\n"},
+		{"calc quoted", "calc", "b45:\"+:\" + b45:\" \"\n", exitOK, "1880\n", ""},
+		{"calc quoted quote", `calc ascii85:"""!"`, "", exitOK, "85\n", ""},
+		{"calc quoted bad digit", `calc b45:"A+b"`, "", exitFail, "", "invalid digit 'b' at position 7"},
+		{"calc missing quote", `calc 1+"2`, "", exitFail, "", "missing closing quote at position 2"},
+		{"calc division by zero", "calc 1 / (2 - 2)", "", exitFail, "", "division by zero at position 2"},
+		{"calc negative", "calc 1 - 2", "", exitFail, "", "negative result -1"},
+		{"calc missing paren", "calc (1 + 2", "", exitFail, "", "missing ) at position 6"},
+		{"seq last", "seq 3", "", exitOK, "1\n2\n3\n", ""},
+		{"seq first last", "seq -sys hex e 11", "", exitOK, "e\nf\n10\n11\n", ""},
+		{"seq step", "seq -sys bin -w 0 10 110", "", exitOK, "000\n010\n100\n110\n", ""},
+		{"seq json", "seq -json 1 2", "", exitOK, `{"input":"","value":"1"}` + "\n" + `{"input":"","value":"2"}` + "\n", ""},
+		{"seq zero step", "seq 1 0 5", "", exitUsage, "", "step can not be zero"},
+		{"count", "count -sys b36 -len 6", "", exitOK, "2176782336\n", ""},
+		{"count lengths", "count -sys bin -min 1 -max 3 -to hex -json", "", exitOK, `{"count":"e"}` + "\n", ""},
+		{"validate", "validate -check luhn 79927398713 79927398710", "", exitFail, "79927398713\tvalid\n79927398710\tinvalid\n", ""},
+		{"validate json", "validate -check damm -json", "5724\n", exitOK, `{"input":"5724","valid":true}` + "\n", ""},
+		{"validate crockford", "validate -sys crockford -check crockford 10* 14U 13~", "", exitFail, "10*\tvalid\n14U\tvalid\n13~\tinvalid\n", ""},
+		{"validate unknown check", "validate -check nope 1", "", exitUsage, "", "unknown check digit algorithm"},
+	}
+	for _, tt := range runTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(strings.Fields(tt.args), strings.NewReader(tt.stdin), &stdout, &stderr)
+			if status != tt.status {
+				t.Errorf("got: %d want: %d (stderr: %s)", status, tt.status, stderr.String())
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("got: %q want: %q", stdout.String(), tt.stdout)
+			}
+			if !strings.Contains(stderr.String(), tt.stderr) {
+				t.Errorf("got: %q want it to contain: %q", stderr.String(), tt.stderr)
+			}
+		})
+	}
+}
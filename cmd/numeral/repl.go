@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/slysterous/numeral"
+	"github.com/slysterous/numeral/cmd/internal/systems"
+)
+
+// replHelp describes the input of the REPL.
+const replHelp = `expressions use + - * / % and parentheses, e.g. hex:ff + b36:zz * 2
+  quote digits that are operators, spaces, colons or quotes, e.g. b45:"A+B",
+  and double a quote within quotes
+  name = expression   assign a variable, _ holds the last result; variables
+                      take precedence over numerals with the same digits
+  :sys name values    define a system, e.g. :sys b36 0-9a-z
+  :sys [name]         list the systems or show the values of one
+  :in name            set the system of operands without a prefix
+  :out name           set the system of the results
+  :vars               list the variables
+  :history            list the history
+  !n, !!              run entry n of the history, or the last one
+  :help               show this help
+  :quit               leave
+`
+
+// replSession is the state of a REPL: its systems, variables and history.
+type replSession struct {
+	systems  map[string][]rune
+	vars     map[string]*big.Int
+	in, out  string
+	history  []string
+	histFile io.Writer
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+// repl reads expressions, assignments and commands from stdin, one per line,
+// until the end of stdin or :quit.
+func repl(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("repl", stderr)
+	from := fs.String("from", "dec", "system of operands without a system prefix")
+	to := fs.String("to", "dec", "system of the results")
+	histPath := fs.String("history", "", "file to load the history from and append it to")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	s := &replSession{
+		systems: make(map[string][]rune),
+		vars:    make(map[string]*big.Int),
+		stdout:  stdout,
+		stderr:  stderr,
+	}
+	for _, name := range []string{*from, *to} {
+		if _, err := s.lookupValues(name); err != nil {
+			fmt.Fprintf(stderr, "numeral repl: %v\n", err)
+			return exitUsage
+		}
+	}
+	s.in, s.out = *from, *to
+	if *histPath != "" {
+		f, err := s.openHistory(*histPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "numeral repl: %v\n", err)
+			return exitFail
+		}
+		defer f.Close()
+		s.histFile = f
+	}
+
+	interactive := isTerminal(stdin)
+	scanner := bufio.NewScanner(stdin)
+	for {
+		if interactive {
+			fmt.Fprint(stdout, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		if !s.exec(strings.TrimSpace(scanner.Text())) {
+			return exitOK
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "numeral repl: %v\n", err)
+		return exitFail
+	}
+	return exitOK
+}
+
+// isTerminal reports whether r is a terminal, in which case the REPL prompts.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// openHistory loads the history from path and opens it for appending.
+func (s *replSession) openHistory(path string) (*os.File, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			s.history = append(s.history, line)
+		}
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+}
+
+// exec runs a line of input and reports whether the REPL goes on.
+func (s *replSession) exec(line string) bool {
+	if line == "" {
+		return true
+	}
+	if strings.HasPrefix(line, "!") {
+		recalled, err := s.recall(line)
+		if err != nil {
+			s.errorf("%v", err)
+			return true
+		}
+		// echo the recalled line, as shells do.
+		fmt.Fprintln(s.stdout, recalled)
+		line = recalled
+	}
+	if line == ":history" {
+		for i, h := range s.history {
+			fmt.Fprintf(s.stdout, "%5d  %s\n", i+1, h)
+		}
+		return true
+	}
+	s.remember(line)
+	if strings.HasPrefix(line, ":") {
+		return s.command(strings.Fields(line[1:]))
+	}
+	s.evaluate(line)
+	return true
+}
+
+// recall returns the line of the history that !n or !! refers to.
+func (s *replSession) recall(ref string) (string, error) {
+	if len(s.history) == 0 {
+		return "", fmt.Errorf("history is empty")
+	}
+	if ref == "!!" {
+		return s.history[len(s.history)-1], nil
+	}
+	i, err := strconv.Atoi(ref[1:])
+	if err != nil || i < 1 || i > len(s.history) {
+		return "", fmt.Errorf("no history entry %s", ref)
+	}
+	return s.history[i-1], nil
+}
+
+// remember appends line to the history and to the history file.
+func (s *replSession) remember(line string) {
+	s.history = append(s.history, line)
+	if s.histFile != nil {
+		fmt.Fprintln(s.histFile, line)
+	}
+}
+
+// command runs a colon command and reports whether the REPL goes on.
+func (s *replSession) command(fields []string) bool {
+	if len(fields) == 0 {
+		s.errorf("missing command, try :help")
+		return true
+	}
+	switch name, args := fields[0], fields[1:]; {
+	case name == "quit" || name == "q":
+		return false
+	case name == "help":
+		fmt.Fprint(s.stdout, replHelp)
+	case name == "sys" && len(args) == 0:
+		for _, name := range s.systemNames() {
+			values, _ := s.system(name)
+			fmt.Fprintf(s.stdout, "%s\t%d\n", name, len(values))
+		}
+	case name == "sys" && len(args) == 1:
+		values, ok := s.system(args[0])
+		if !ok {
+			s.errorf("unknown system %q", args[0])
+			return true
+		}
+		fmt.Fprintln(s.stdout, string(values))
+	case name == "sys" && len(args) == 2:
+		if !isIdentifier(args[0]) {
+			s.errorf("invalid system name %q", args[0])
+			return true
+		}
+		values, err := systems.ParseValues(args[1])
+		if err != nil {
+			s.errorf("%v", err)
+			return true
+		}
+		s.systems[args[0]] = values
+	case (name == "in" || name == "out") && len(args) == 1:
+		if _, err := s.lookupValues(args[0]); err != nil {
+			s.errorf("%v", err)
+			return true
+		}
+		if name == "in" {
+			s.in = args[0]
+		} else {
+			s.out = args[0]
+		}
+	case (name == "in" || name == "out") && len(args) == 0:
+		fmt.Fprintln(s.stdout, map[string]string{"in": s.in, "out": s.out}[name])
+	case name == "vars":
+		names := make([]string, 0, len(s.vars))
+		for name := range s.vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(s.stdout, "%s = %s\n", name, s.format(s.vars[name]))
+		}
+	default:
+		s.errorf("unknown command :%s, try :help", strings.Join(fields, " "))
+	}
+	return true
+}
+
+// evaluate evaluates an expression or an assignment and prints the result.
+func (s *replSession) evaluate(line string) {
+	name, expr := "", line
+	// an = within quotes is a digit, not an assignment.
+	if i := strings.IndexRune(line, '='); i >= 0 && !strings.ContainsRune(line[:i], '"') {
+		name, expr = strings.TrimSpace(line[:i]), line[i+1:]
+		if !isIdentifier(name) {
+			s.errorf("invalid variable name %q", name)
+			return
+		}
+	}
+	// the input system is looked up again as :sys may have redefined it.
+	in, _ := s.lookupValues(s.in)
+	e := evaluator{input: in, system: s.system, variable: s.variable}
+	x, err := e.eval(expr)
+	if err != nil {
+		// point at the position within the whole line.
+		offset := len([]rune(line)) - len([]rune(expr))
+		if ee, ok := err.(*exprError); ok {
+			err = &exprError{pos: ee.pos + offset, msg: ee.msg}
+		}
+		s.errorf("%v", err)
+		fmt.Fprint(s.stderr, caret(line, err))
+		return
+	}
+	if x.Sign() < 0 {
+		s.errorf("negative result %v can not be written as a numeral", x)
+		return
+	}
+	s.vars["_"] = x
+	if name != "" {
+		s.vars[name] = x
+	}
+	fmt.Fprintln(s.stdout, s.format(x))
+}
+
+// format writes x in the output system.
+func (s *replSession) format(x *big.Int) string {
+	out, _ := s.lookupValues(s.out)
+	n, _ := numeral.NewFromBigInt(out, x)
+	return n.String()
+}
+
+// system looks up a system defined in the session or a built-in one.
+func (s *replSession) system(name string) ([]rune, bool) {
+	if values, ok := s.systems[name]; ok {
+		return values, true
+	}
+	return systems.Values(name)
+}
+
+// systemNames returns the names of all systems in order.
+func (s *replSession) systemNames() []string {
+	names := systems.Names()
+	for name := range s.systems {
+		if _, ok := systems.Values(name); !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupValues returns the values of a system of the session, a built-in one
+// or the digit values of a spec.
+func (s *replSession) lookupValues(spec string) ([]rune, error) {
+	if values, ok := s.systems[spec]; ok {
+		return values, nil
+	}
+	return systems.Lookup(spec)
+}
+
+// variable looks up the value of a variable.
+func (s *replSession) variable(name string) (*big.Int, bool) {
+	x, ok := s.vars[name]
+	return x, ok
+}
+
+// errorf prints an error.
+func (s *replSession) errorf(format string, a ...interface{}) {
+	fmt.Fprintf(s.stderr, "error: "+format+"\n", a...)
+}
+
+// isIdentifier reports whether name is made of letters, digits and
+// underscores and does not start with a digit.
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if !(c == '_' || unicode.IsLetter(c) || (i > 0 && unicode.IsDigit(c))) {
+			return false
+		}
+	}
+	return true
+}
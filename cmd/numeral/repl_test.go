@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runREPL(t *testing.T, input string, args ...string) (string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	if status := run(append([]string{"repl"}, args...), strings.NewReader(input), &stdout, &stderr); status != exitOK {
+		t.Fatalf("got: %d want: %d (stderr: %s)", status, exitOK, stderr.String())
+	}
+	return stdout.String(), stderr.String()
+}
+
+func TestREPL(t *testing.T) {
+	replTests := []struct {
+		name   string
+		input  string
+		stdout string
+		stderr string
+	}{
+		{"expression", "hex:ff + b36:z * (1 + 1)\n", "325\n", ""},
+		{"assignment", "x = hex:10\ny = x * 2\nx + y + _\n", "16\n32\n80\n", ""},
+		{"systems", ":sys ab 0-1\n:in ab\n:out hex\n11 * 101\n:in\n", "f\nab\n", ""},
+		{"redefined system", ":sys tri 012\n:out tri\n5\n:sys tri abc\n5\n", "12\nbc\n", ""},
+		{"show system", ":sys oct\n", "01234567\n", ""},
+		{"vars", "a = 10\n:out hex\n:vars\n", "10\n_ = a\na = a\n", ""},
+		{"quit", "1\n:quit\n2\n", "1\n", ""},
+		{"bad digit", "x = 1 + hex:fg\n", "", "error: invalid digit 'g' at position 13\n  x = 1 + hex:fg\n               ^\n"},
+		{"quoted", "x = z85:\"=\"\nz85:\"==\" - x\n", "66\n5610\n", ""},
+		{"division by zero", "7 % (1 - 1)\n", "", "error: division by zero at position 2\n"},
+		{"negative", "1 - 2\n", "", "error: negative result -1"},
+		{"unknown variable", "2 * nope\n", "", "error: invalid digit 'n' at position 4\n"},
+		{"invalid variable name", "1x = 2\n", "", `error: invalid variable name "1x"`},
+		{"unknown system", ":out z\n", "", "error: numeral: "},
+		{"unknown command", ":frobnicate\n", "", "error: unknown command :frobnicate"},
+		{"history", "1 + 1\n:out bin\n!1\n!!\n:history\n", "2\n1 + 1\n10\n1 + 1\n10\n    1  1 + 1\n    2  :out bin\n    3  1 + 1\n    4  1 + 1\n", ""},
+		{"empty history", "!!\n", "", "error: history is empty\n"},
+		{"missing history entry", "1\n!7\n", "1\n", "error: no history entry !7\n"},
+	}
+	for _, tt := range replTests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr := runREPL(t, tt.input)
+			if stdout != tt.stdout {
+				t.Errorf("got: %q want: %q", stdout, tt.stdout)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("got: %q want it to contain: %q", stderr, tt.stderr)
+			}
+			if tt.stderr == "" && stderr != "" {
+				t.Errorf("got: %q want no errors", stderr)
+			}
+		})
+	}
+}
+
+func TestREPLHistoryFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "numeral-repl")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history")
+
+	runREPL(t, "6 * 7\n", "-history", path)
+	// the history of an earlier session can be recalled.
+	stdout, _ := runREPL(t, "!1\n", "-history", path, "-to", "hex")
+	if want := "6 * 7\n2a\n"; stdout != want {
+		t.Errorf("got: %q want: %q", stdout, want)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if want := "6 * 7\n6 * 7\n"; string(b) != want {
+		t.Errorf("got: %q want: %q", b, want)
+	}
+}
@@ -0,0 +1,69 @@
+package numeral
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Counter is a numeral that can be shared between goroutines. A Numeral is
+// changed in place by Increment, so using one from several goroutines is a
+// data race; a Counter guards it and hands out immutable string snapshots
+// instead.
+//
+// Every call takes the same mutex and Next allocates the string it returns, so
+// a Counter does not scale under contention. Goroutines that need many values
+// should Reserve blocks and count within them on their own, and hot paths
+// should use an AtomicCounter.
+type Counter struct {
+	mu      sync.Mutex
+	values  []rune
+	current *Numeral
+}
+
+// NewCounter creates a counter whose first value is start, which must have at
+// least one digit.
+func NewCounter(start Numeral) (*Counter, error) {
+	if start.empty() {
+		return nil, fmt.Errorf("numeral: can not count from an empty numeral")
+	}
+	return &Counter{values: start.digitValues, current: start.clone()}, nil
+}
+
+// Next returns the current value of the counter and advances it by one.
+func (c *Counter) Next() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.current.String()
+	c.current.Increment()
+	return s
+}
+
+// Reserve allocates a block of n consecutive values at once, e.g. to hand out
+// to a worker, and returns the first and the last of them. Next continues
+// right after the block.
+func (c *Counter) Reserve(n uint64) (first, last string, err error) {
+	if n == 0 {
+		return "", "", fmt.Errorf("numeral: can not Reserve an empty block")
+	}
+	// the digit values never change, so the distance is computed unlocked.
+	toLast, _ := newFromBig(c.values, new(big.Int).SetUint64(n-1))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	end := c.current.clone()
+	if err := end.Step(*toLast); err != nil {
+		return "", "", err
+	}
+	first, last = c.current.String(), end.String()
+	end.Increment()
+	c.current = end
+	return first, last, nil
+}
+
+// Peek returns the value that the next call to Next would return, without
+// advancing the counter.
+func (c *Counter) Peek() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current.String()
+}
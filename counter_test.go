@@ -0,0 +1,120 @@
+package numeral_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestCounter(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "zx")
+	c, err := numeral.NewCounter(*start)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got := c.Peek(); got != "zx" {
+		t.Errorf("got: %s want: zx", got)
+	}
+	for _, want := range []string{"zx", "zy", "zz", "100"} {
+		if got := c.Next(); got != want {
+			t.Errorf("got: %s want: %s", got, want)
+		}
+	}
+	first, last, err := c.Reserve(36)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if first != "101" || last != "110" {
+		t.Errorf("got: %s-%s want: 101-110", first, last)
+	}
+	if got := c.Next(); got != "111" {
+		t.Errorf("got: %s want: 111", got)
+	}
+	if _, _, err := c.Reserve(0); err == nil {
+		t.Error("expected error to be thrown on an empty block")
+	}
+	if start.String() != "zx" {
+		t.Errorf("got: %s want the start numeral untouched", start.String())
+	}
+}
+
+func TestNewCounterEmptyThrowsErr(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "")
+	if _, err := numeral.NewCounter(*start); err == nil {
+		t.Error("expected error to be thrown on an empty start")
+	}
+	if _, err := numeral.NewCounter(numeral.Numeral{}); err == nil {
+		t.Error("expected error to be thrown on the zero Numeral")
+	}
+}
+
+func TestCounterConcurrent(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "0")
+	c, _ := numeral.NewCounter(*start)
+	const workers, perWorker = 8, 500
+	results := make(chan string, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				results <- c.Next()
+				c.Peek()
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+	seen := make(map[string]bool)
+	for s := range results {
+		if seen[s] {
+			t.Fatalf("got: %s twice", s)
+		}
+		seen[s] = true
+	}
+	if len(seen) != workers*perWorker {
+		t.Errorf("got: %d values want: %d", len(seen), workers*perWorker)
+	}
+	want, _ := numeral.NewFromDecimal(testValues, workers*perWorker)
+	if got := c.Peek(); got != want.String() {
+		t.Errorf("got: %s want: %s", got, want.String())
+	}
+}
+
+func TestCounterReserveConcurrent(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "0")
+	c, _ := numeral.NewCounter(*start)
+	const workers, perWorker, blockSize = 8, 100, 3
+	type block struct{ first, last string }
+	results := make(chan block, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				first, last, err := c.Reserve(blockSize)
+				if err != nil {
+					t.Errorf("expected nil got err: %v", err)
+					return
+				}
+				results <- block{first, last}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+	seen := make(map[string]bool)
+	for b := range results {
+		if seen[b.first] {
+			t.Fatalf("got: block %s-%s twice", b.first, b.last)
+		}
+		seen[b.first] = true
+	}
+	want, _ := numeral.NewFromDecimal(testValues, workers*perWorker*blockSize)
+	if got := c.Peek(); got != want.String() {
+		t.Errorf("got: %s want: %s", got, want.String())
+	}
+}
@@ -0,0 +1,219 @@
+package numeral
+
+import (
+	"fmt"
+	"math"
+)
+
+// DeBruijnSequence streams the shortest string that contains every numeral of
+// a fixed amount of digits as a substring, one digit at a time.
+//
+//	seq, _ := numeral.DeBruijn([]rune{'0', '1'}, 3)
+//	for seq.Next() {
+//	    fmt.Print(string(seq.Rune()))
+//	}
+//	// 0001011100
+type DeBruijnSequence struct {
+	values  []rune
+	n       int
+	length  int
+	words   prenecklace
+	started bool
+	pending []int
+	prefix  []int
+	emitted int
+	current rune
+}
+
+// DeBruijn creates the De Bruijn sequence of numerals with n digits under the
+// system defined by values. The sequence is generated lazily, as the
+// concatenation of the Lyndon words whose length divides n, followed by the
+// n-1 digits that close the cycle.
+func DeBruijn(values []rune, n int) (*DeBruijnSequence, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("numeral: no digit values provided")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("numeral: invalid amount of digits: %d", n)
+	}
+	cycle := math.Pow(float64(len(values)), float64(n))
+	if cycle+float64(n) > math.MaxInt32 {
+		return nil, fmt.Errorf("numeral: De Bruijn sequence of %d digits in base %d is too long", n, len(values))
+	}
+	return &DeBruijnSequence{
+		values: values,
+		n:      n,
+		length: int(cycle) + n - 1,
+		words:  newPrenecklace(len(values), n),
+	}, nil
+}
+
+// Len returns the total amount of digits in the sequence.
+func (s *DeBruijnSequence) Len() int {
+	return s.length
+}
+
+// Next advances the sequence to its next digit. It returns false when the
+// sequence is over.
+func (s *DeBruijnSequence) Next() bool {
+	if s.emitted >= s.length {
+		return false
+	}
+	for len(s.pending) == 0 {
+		if s.started && !s.words.next() {
+			// the cycle is over, repeat its start so that the last windows wrap.
+			s.pending = s.prefix
+			break
+		}
+		s.started = true
+		// a necklace contributes its Lyndon root.
+		if s.n%s.words.p == 0 {
+			s.pending = append(s.pending, s.words.a[:s.words.p]...)
+		}
+	}
+	i := s.pending[0]
+	s.pending = s.pending[1:]
+	if len(s.prefix) < s.n-1 && s.emitted < s.n-1 {
+		s.prefix = append(s.prefix, i)
+	}
+	s.current = s.values[i]
+	s.emitted++
+	return true
+}
+
+// Rune returns the digit the sequence is currently at.
+func (s *DeBruijnSequence) Rune() rune {
+	return s.current
+}
+
+// DeBruijnWindow returns the numeral found in the window that starts at pos of
+// the De Bruijn sequence of numerals with n digits. Every numeral appears in
+// exactly one window. The window is found without streaming the sequence, in
+// time polynomial in n and logarithmic in the amount of values.
+func DeBruijnWindow(values []rune, n int, pos int) (*Numeral, error) {
+	seq, err := DeBruijn(values, n)
+	if err != nil {
+		return nil, err
+	}
+	if pos < 0 || pos > seq.Len()-n {
+		return nil, fmt.Errorf("numeral: window position %d out of range [0, %d]", pos, seq.Len()-n)
+	}
+	// the Lyndon root of a necklace starts after as many digits as there are
+	// numerals whose smallest rotation is below the necklace, so the window
+	// starts within the root of the largest necklace with at most pos of them.
+	k := len(values)
+	necklace := make([]int, n)
+	for i := range necklace {
+		lo, hi := 0, k-1
+		for lo < hi {
+			necklace[i] = (lo + hi + 1) / 2
+			if rotationsBelow(k, necklace) <= uint64(pos) {
+				lo = necklace[i]
+			} else {
+				hi = necklace[i] - 1
+			}
+		}
+		necklace[i] = lo
+	}
+	offset := pos - int(rotationsBelow(k, necklace))
+	words := prenecklace{k: k, a: necklace, p: period(necklace)}
+	indexes := append([]int(nil), words.a[offset:words.p]...)
+	for len(indexes) < n {
+		if !words.next() {
+			// the cycle is over, the sequence ends with the zeros it starts with.
+			indexes = append(indexes, make([]int, n-len(indexes))...)
+			break
+		}
+		if n%words.p == 0 {
+			indexes = append(indexes, words.a[:words.p]...)
+		}
+	}
+	return newFromIndexes(values, indexes[:n]), nil
+}
+
+// period returns the length of the smallest block that repeats to w.
+func period(w []int) int {
+	for p := 1; p < len(w); p++ {
+		if len(w)%p != 0 {
+			continue
+		}
+		i := p
+		for i < len(w) && w[i] == w[i-p] {
+			i++
+		}
+		if i == len(w) {
+			return p
+		}
+	}
+	return len(w)
+}
+
+// rotationsBelow returns the amount of words of len(w) digits over k values
+// that have a rotation smaller than w. It counts the complement, the cyclic
+// words whose every rotation is at least w, as closed walks of the KMP
+// automaton of w: the automaton state only depends on the last len(w) digits,
+// so a cyclic word is read from the state it ends in.
+func rotationsBelow(k int, w []int) uint64 {
+	n := len(w)
+	// fail[j] is the longest proper border of w[:j].
+	fail := make([]int, n+1)
+	for j := 2; j <= n; j++ {
+		b := fail[j-1]
+		for b > 0 && w[b] != w[j-1] {
+			b = fail[b]
+		}
+		if w[b] == w[j-1] {
+			b++
+		}
+		fail[j] = b
+	}
+	// a rotation is below w once a border of the read digits, a prefix of w,
+	// is followed by a smaller digit than w has there. Digits above every such
+	// digit of w lead back to the start, the largest one extends a border.
+	least := make([]int, n+1)
+	target := make([]int, n+1)
+	for j := 0; j <= n; j++ {
+		b := j
+		if b == n {
+			b = fail[n]
+		}
+		least[j], target[j] = -1, 0
+		for {
+			if w[b] > least[j] {
+				least[j], target[j] = w[b], b+1
+			}
+			if b == 0 {
+				break
+			}
+			b = fail[b]
+		}
+	}
+	var atLeast uint64
+	walks := make([]uint64, n+1)
+	step := make([]uint64, n+1)
+	for start := 0; start <= n; start++ {
+		for j := range walks {
+			walks[j] = 0
+		}
+		walks[start] = 1
+		for i := 0; i < n; i++ {
+			for j := range step {
+				step[j] = 0
+			}
+			for j, c := range walks {
+				if c == 0 {
+					continue
+				}
+				step[target[j]] += c
+				step[0] += c * uint64(k-1-least[j])
+			}
+			walks, step = step, walks
+		}
+		atLeast += walks[start]
+	}
+	total := uint64(1)
+	for i := 0; i < n; i++ {
+		total *= uint64(k)
+	}
+	return total - atLeast
+}
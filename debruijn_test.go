@@ -0,0 +1,133 @@
+package numeral_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestDeBruijn(t *testing.T) {
+	deBruijnTests := []struct {
+		values []rune
+		n      int
+		want   string
+	}{
+		{[]rune{'0', '1'}, 1, "01"},
+		{[]rune{'0', '1'}, 3, "0001011100"},
+		{[]rune{'a', 'b', 'c'}, 2, "aabacbbcca"},
+		{[]rune{'x'}, 3, "xxx"},
+	}
+	for _, tt := range deBruijnTests {
+		t.Run(tt.want, func(t *testing.T) {
+			seq, err := numeral.DeBruijn(tt.values, tt.n)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			var b strings.Builder
+			for seq.Next() {
+				b.WriteRune(seq.Rune())
+			}
+			if got, want := b.String(), tt.want; got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+			if got, want := seq.Len(), len(tt.want); got != want {
+				t.Errorf("Len got: %d want: %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDeBruijnContainsEveryNumeral(t *testing.T) {
+	values := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
+	seq, _ := numeral.DeBruijn(values, 4)
+	var b strings.Builder
+	for seq.Next() {
+		b.WriteRune(seq.Rune())
+	}
+	s := b.String()
+	if got, want := len(s), 10003; got != want {
+		t.Fatalf("got length: %d want: %d", got, want)
+	}
+	seen := make(map[string]bool)
+	for i := 0; i+4 <= len(s); i++ {
+		seen[s[i:i+4]] = true
+	}
+	if got, want := len(seen), 10000; got != want {
+		t.Errorf("got: %d distinct windows want: %d", got, want)
+	}
+}
+
+func TestDeBruijnWindow(t *testing.T) {
+	windowTests := []struct {
+		pos  int
+		want string
+	}{
+		{0, "000"},
+		{3, "101"},
+		{7, "100"},
+	}
+	for _, tt := range windowTests {
+		t.Run(tt.want, func(t *testing.T) {
+			number, err := numeral.DeBruijnWindow([]rune{'0', '1'}, 3, tt.pos)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got, want := number.String(), tt.want; got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestDeBruijnWindowMatchesSequence(t *testing.T) {
+	windowTests := []struct {
+		values []rune
+		n      int
+	}{
+		{[]rune{'0', '1'}, 1},
+		{[]rune{'0', '1'}, 6},
+		{[]rune{'0', '1'}, 8},
+		{[]rune{'a', 'b', 'c'}, 4},
+		{[]rune{'0', '1', '2', '3'}, 4},
+		{[]rune{'x'}, 3},
+		{hexValues, 2},
+		{hexValues, 1},
+	}
+	for _, tt := range windowTests {
+		t.Run(string(tt.values)+"/"+strconv.Itoa(tt.n), func(t *testing.T) {
+			seq, _ := numeral.DeBruijn(tt.values, tt.n)
+			var digits []rune
+			for seq.Next() {
+				digits = append(digits, seq.Rune())
+			}
+			for pos := 0; pos+tt.n <= len(digits); pos++ {
+				number, err := numeral.DeBruijnWindow(tt.values, tt.n, pos)
+				if err != nil {
+					t.Fatalf("expected nil got err: %v", err)
+				}
+				if got, want := number.String(), string(digits[pos:pos+tt.n]); got != want {
+					t.Fatalf("pos %d got: %s want: %s", pos, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeBruijnWindowLongSequence(t *testing.T) {
+	// the last window of a sequence of about 2^30 digits wraps to its start.
+	number, err := numeral.DeBruijnWindow([]rune{'0', '1'}, 30, 1<<30-1)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, want := number.String(), "1"+strings.Repeat("0", 29); got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+}
+
+func TestDeBruijnWindowOutOfRangeThrowsErr(t *testing.T) {
+	if _, err := numeral.DeBruijnWindow([]rune{'0', '1'}, 3, 8); err == nil {
+		t.Errorf("expected error to be thrown on DeBruijnWindow")
+	}
+}
@@ -0,0 +1,121 @@
+package numeral
+
+import (
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// Base58BitcoinValues are the digit values of the base58 alphabet used by
+// Bitcoin, which leaves out 0, O, I and l as they are easy to mix up.
+var Base58BitcoinValues = []rune("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+// Encode encodes src as a numeral of the system defined by values, the way
+// base58 does. Every leading zero byte of src is preserved as a leading zero
+// digit (values[0]) so that decoding gives back exactly the same bytes.
+// Unlike NewFromDecimal the input can be of any length.
+func Encode(values []rune, src []byte) (string, error) {
+	dst, err := AppendEncode(values, nil, src)
+	if err != nil {
+		return "", err
+	}
+	return string(dst), nil
+}
+
+// AppendEncode appends the encoding of src to dst and returns the extended
+// buffer.
+func AppendEncode(values []rune, dst, src []byte) ([]byte, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed to encode bytes, got: %d", len(values))
+	}
+	for _, d := range encodeIndexes(len(values), src) {
+		dst = appendRune(dst, values[d])
+	}
+	return dst, nil
+}
+
+// Decode decodes a string produced by Encode back to the bytes it encodes. An
+// invalid digit is reported with a *DigitError.
+func Decode(values []rune, s string) ([]byte, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("numeral: at least 2 digit values are needed to decode bytes, got: %d", len(values))
+	}
+	indexes := make([]int, 0, len(s))
+	pos := 0
+	for _, c := range s {
+		i := indexOf(c, values)
+		if i == -1 {
+			return nil, &DigitError{Digit: c, Position: pos}
+		}
+		indexes = append(indexes, i)
+		pos++
+	}
+	return decodeIndexes(len(values), indexes), nil
+}
+
+// encodeIndexes converts src to the digit positions of a system with the given
+// base, starting from the most significant digit. Leading zero bytes become
+// leading zero digits.
+func encodeIndexes(base int, src []byte) []int {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+	// every byte needs at most log(256)/log(base) digits.
+	size := int(float64(len(src)-zeros)*math.Log(256)/math.Log(float64(base))) + 1
+	digits := make([]int, size)
+	high := size - 1
+	for _, b := range src[zeros:] {
+		carry := int(b)
+		j := size - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 256 * digits[j]
+			digits[j] = carry % base
+			carry /= base
+		}
+		high = j
+	}
+	// skip the unused leading digits of the buffer.
+	start := 0
+	for start < size && digits[start] == 0 {
+		start++
+	}
+	indexes := make([]int, zeros, zeros+size-start)
+	return append(indexes, digits[start:]...)
+}
+
+// decodeIndexes converts the digit positions of a system with the given base
+// back to bytes. Leading zero digits become leading zero bytes.
+func decodeIndexes(base int, indexes []int) []byte {
+	zeros := 0
+	for zeros < len(indexes) && indexes[zeros] == 0 {
+		zeros++
+	}
+	// every digit needs at most log(base)/log(256) bytes.
+	size := int(float64(len(indexes)-zeros)*math.Log(float64(base))/math.Log(256)) + 1
+	buf := make([]byte, size)
+	high := size - 1
+	for _, d := range indexes[zeros:] {
+		carry := d
+		j := size - 1
+		for ; j > high || carry != 0; j-- {
+			carry += base * int(buf[j])
+			buf[j] = byte(carry)
+			carry >>= 8
+		}
+		high = j
+	}
+	start := 0
+	for start < size && buf[start] == 0 {
+		start++
+	}
+	out := make([]byte, zeros, zeros+size-start)
+	return append(out, buf[start:]...)
+}
+
+// appendRune appends the UTF-8 encoding of r to dst.
+func appendRune(dst []byte, r rune) []byte {
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(dst, buf[:n]...)
+}
@@ -0,0 +1,108 @@
+package numeral_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+// base58Tests are the known answers of the base58 encoding used by Bitcoin.
+var base58Tests = []struct {
+	hex  string
+	want string
+}{
+	{"", ""},
+	{"61", "2g"},
+	{"626262", "a3gV"},
+	{"636363", "aPEr"},
+	{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+	{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+	{"516b6fcd0f", "ABnLTmg"},
+	{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+	{"572e4794", "3EFU7m"},
+	{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+	{"10c8511e", "Rt5zm"},
+	{"00000000000000000000", "1111111111"},
+}
+
+func TestEncodeBase58(t *testing.T) {
+	for _, tt := range base58Tests {
+		t.Run(tt.want, func(t *testing.T) {
+			src, _ := hex.DecodeString(tt.hex)
+			got, err := numeral.Encode(numeral.Base58BitcoinValues, src)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBase58(t *testing.T) {
+	for _, tt := range base58Tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := numeral.Decode(numeral.Base58BitcoinValues, tt.want)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if hex.EncodeToString(got) != tt.hex {
+				t.Errorf("got: %x want: %s", got, tt.hex)
+			}
+		})
+	}
+}
+
+func TestAppendEncode(t *testing.T) {
+	got, err := numeral.AppendEncode(numeral.Base58BitcoinValues, []byte("id:"), []byte("a"))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if string(got) != "id:2g" {
+		t.Errorf("got: %s want: id:2g", got)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	alphabets := [][]rune{
+		{'0', '1'},
+		testValues,
+		[]rune("αβγδεζηθ"),
+	}
+	inputs := [][]byte{
+		{0},
+		{0, 0, 1},
+		{255, 255, 255, 255, 255, 255, 255, 255, 255},
+		bytes.Repeat([]byte{0xde, 0xad, 0xbe, 0xef}, 16),
+	}
+	for _, values := range alphabets {
+		for _, src := range inputs {
+			s, err := numeral.Encode(values, src)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			got, err := numeral.Decode(values, s)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if !bytes.Equal(got, src) {
+				t.Errorf("got: %x want: %x", got, src)
+			}
+		}
+	}
+}
+
+func TestDecodeInvalidDigitThrowsErr(t *testing.T) {
+	_, err := numeral.Decode(numeral.Base58BitcoinValues, "3EF0")
+	var de *numeral.DigitError
+	if !errors.As(err, &de) {
+		t.Fatalf("got: %v want a *DigitError", err)
+	}
+	if de.Digit != '0' || de.Position != 3 {
+		t.Errorf("got: %q at %d want: '0' at 3", de.Digit, de.Position)
+	}
+}
@@ -0,0 +1,101 @@
+package numeral
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/slysterous/numeral/internal/atomicfile"
+)
+
+// FileCounter is a counter that survives restarts and crashes without ever
+// issuing a value twice. Its state lives in a local file that is written
+// ahead: before a value is issued, the file already records a value past it.
+// To avoid a sync on every value, values are leased from the file in blocks;
+// after a crash the counter resumes past the whole block, so the unused rest
+// of it is skipped. It is safe for concurrent use, but not for use by several
+// processes at once.
+type FileCounter struct {
+	mu        sync.Mutex
+	path      string
+	current   *Numeral
+	lease     *Numeral
+	leaseSize uint64
+	remaining uint64
+	closed    bool
+}
+
+// OpenFileCounter opens the counter stored at path, leasing leaseSize values
+// at a time. When the file does not exist yet, the counter starts from start.
+// Either way the counter uses the digit values of start.
+func OpenFileCounter(path string, start Numeral, leaseSize uint64) (*FileCounter, error) {
+	if leaseSize == 0 {
+		return nil, fmt.Errorf("numeral: invalid lease size: %d", leaseSize)
+	}
+	if start.empty() {
+		return nil, fmt.Errorf("numeral: can not count from an empty numeral")
+	}
+	current := start.clone()
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+		s := strings.TrimSpace(string(data))
+		if s == "" {
+			// an empty numeral never advances, so it would issue one value
+			// over and over.
+			return nil, fmt.Errorf("numeral: invalid counter file %s: empty", path)
+		}
+		current, err = NewNumeral(start.digitValues, s)
+		if err != nil {
+			return nil, fmt.Errorf("numeral: invalid counter file %s: %v", path, err)
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	lease, _ := newFromBig(start.digitValues, new(big.Int).SetUint64(leaseSize))
+	return &FileCounter{
+		path:      path,
+		current:   current,
+		lease:     lease,
+		leaseSize: leaseSize,
+	}, nil
+}
+
+// Next returns the current value of the counter and advances it by one. It
+// writes the file whenever a new block has to be leased.
+func (c *FileCounter) Next() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return "", fmt.Errorf("numeral: counter %s is closed", c.path)
+	}
+	if c.remaining == 0 {
+		end := c.current.clone()
+		if err := end.Step(*c.lease); err != nil {
+			return "", err
+		}
+		if err := atomicfile.WriteFile(c.path, []byte(end.String()+"\n")); err != nil {
+			return "", err
+		}
+		c.remaining = c.leaseSize
+	}
+	s := c.current.String()
+	c.current.Increment()
+	c.remaining--
+	return s, nil
+}
+
+// Close records the exact value the counter stopped at, so that the rest of
+// the current block is not skipped when it is opened again.
+func (c *FileCounter) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return atomicfile.WriteFile(c.path, []byte(c.current.String()+"\n"))
+}
@@ -0,0 +1,107 @@
+package numeral_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func tempCounterPath(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "numeral")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	return filepath.Join(dir, "counter"), func() { os.RemoveAll(dir) }
+}
+
+func TestFileCounterResumesPastLeaseAfterCrash(t *testing.T) {
+	path, cleanup := tempCounterPath(t)
+	defer cleanup()
+	start, _ := numeral.NewNumeral(testValues, "zw")
+	c, err := numeral.OpenFileCounter(path, *start, 4)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	for _, want := range []string{"zw", "zx", "zy", "zz", "100"} {
+		got, err := c.Next()
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if got != want {
+			t.Errorf("got: %s want: %s", got, want)
+		}
+	}
+	// the second lease covers 100 to 103.
+	data, _ := ioutil.ReadFile(path)
+	if got := strings.TrimSpace(string(data)); got != "104" {
+		t.Errorf("got: %s in the file want: 104", got)
+	}
+
+	// reopen without closing, as after a crash.
+	c, err = numeral.OpenFileCounter(path, *start, 4)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, _ := c.Next(); got != "104" {
+		t.Errorf("got: %s want: 104", got)
+	}
+}
+
+func TestFileCounterClose(t *testing.T) {
+	path, cleanup := tempCounterPath(t)
+	defer cleanup()
+	start, _ := numeral.NewNumeral(decimalValues, "0")
+	c, _ := numeral.OpenFileCounter(path, *start, 100)
+	for i := 0; i < 3; i++ {
+		c.Next()
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if _, err := c.Next(); err == nil {
+		t.Error("expected error to be thrown on a closed counter")
+	}
+	c, _ = numeral.OpenFileCounter(path, *start, 100)
+	if got, _ := c.Next(); got != "3" {
+		t.Errorf("got: %s want: 3", got)
+	}
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf("got: %d files want only the counter file", len(files))
+	}
+}
+
+func TestOpenFileCounterInvalid(t *testing.T) {
+	path, cleanup := tempCounterPath(t)
+	defer cleanup()
+	start, _ := numeral.NewNumeral(decimalValues, "0")
+	if _, err := numeral.OpenFileCounter(path, *start, 0); err == nil {
+		t.Error("expected error to be thrown on an empty lease")
+	}
+	ioutil.WriteFile(path, []byte("12x\n"), 0644)
+	if _, err := numeral.OpenFileCounter(path, *start, 10); err == nil {
+		t.Error("expected error to be thrown on a corrupt counter file")
+	}
+}
+
+func TestOpenFileCounterEmptyThrowsErr(t *testing.T) {
+	path, cleanup := tempCounterPath(t)
+	defer cleanup()
+	start, _ := numeral.NewNumeral(decimalValues, "0")
+	for _, data := range []string{"", " \n"} {
+		ioutil.WriteFile(path, []byte(data), 0644)
+		if _, err := numeral.OpenFileCounter(path, *start, 10); err == nil {
+			t.Errorf("expected error to be thrown on counter file %q", data)
+		}
+	}
+	os.Remove(path)
+	empty, _ := numeral.NewNumeral(decimalValues, "")
+	if _, err := numeral.OpenFileCounter(path, *empty, 10); err == nil {
+		t.Error("expected error to be thrown on an empty start")
+	}
+}
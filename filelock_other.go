@@ -0,0 +1,26 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package numeral
+
+import (
+	"os"
+	"sync"
+)
+
+// fileLocks stands in for file locks on platforms without flock, such as
+// js/wasm, plan9, solaris and aix. It only excludes the goroutines of this
+// process, so there a FileStore must not be shared between processes.
+var fileLocks sync.Mutex
+
+// lockFile takes the process wide lock, waiting for it if needed.
+func lockFile(f *os.File) error {
+	fileLocks.Lock()
+	return nil
+}
+
+// unlockFile releases the lock taken by lockFile.
+func unlockFile(f *os.File) error {
+	fileLocks.Unlock()
+	return nil
+}
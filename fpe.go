@@ -0,0 +1,280 @@
+package numeral
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// FF1 is the FF1 format preserving encryption mode of NIST SP 800-38G over
+// AES. It encrypts a numeral into another numeral of the same length and
+// digit values.
+type FF1 struct {
+	block  cipher.Block
+	values []rune
+}
+
+// NewFF1 creates an FF1 cipher with an AES key of 16, 24 or 32 bytes for the
+// system defined by values, which must have between 2 and 65536 digit values.
+func NewFF1(key []byte, values []rune) (*FF1, error) {
+	block, err := newFPEBlock(key, values)
+	if err != nil {
+		return nil, err
+	}
+	return &FF1{block: block, values: values}, nil
+}
+
+// Encrypt encrypts the Numeral n with tweak, which can be of any length.
+func (c *FF1) Encrypt(n Numeral, tweak []byte) (*Numeral, error) {
+	return c.crypt(n, tweak, true)
+}
+
+// Decrypt decrypts the Numeral n that was encrypted with tweak.
+func (c *FF1) Decrypt(n Numeral, tweak []byte) (*Numeral, error) {
+	return c.crypt(n, tweak, false)
+}
+
+// crypt runs the 10 Feistel rounds of FF1 forwards or backwards.
+func (c *FF1) crypt(n Numeral, tweak []byte, encrypt bool) (*Numeral, error) {
+	radix := len(c.values)
+	x, err := fpeIndexes(n, c.values, 1<<32-1)
+	if err != nil {
+		return nil, err
+	}
+	length := len(x)
+	u := length / 2
+	v := length - u
+	a, b := x[:u], x[u:]
+
+	// the byte length of a half and of the round function output.
+	bytes := (ceilLog2Pow(radix, v) + 7) / 8
+	d := 4*((bytes+3)/4) + 4
+
+	p := make([]byte, 16)
+	p[0], p[1], p[2] = 1, 2, 1
+	p[3], p[4], p[5] = byte(radix>>16), byte(radix>>8), byte(radix)
+	p[6] = 10
+	p[7] = byte(u)
+	binary.BigEndian.PutUint32(p[8:12], uint32(length))
+	binary.BigEndian.PutUint32(p[12:16], uint32(len(tweak)))
+
+	pad := (16 - (len(tweak)+bytes+1)%16) % 16
+	q := make([]byte, len(tweak)+pad+1+bytes)
+	copy(q, tweak)
+
+	mac := make([]byte, 16)
+	s := make([]byte, ((d+15)/16)*16)
+	for round := 0; round < 10; round++ {
+		i := round
+		if !encrypt {
+			i = 9 - round
+		}
+		// encryption feeds B into the round function, decryption A.
+		in := b
+		if !encrypt {
+			in = a
+		}
+		q[len(tweak)+pad] = byte(i)
+		num := fpeNum(radix, in).Bytes()
+		tail := q[len(q)-bytes:]
+		for j := range tail {
+			tail[j] = 0
+		}
+		copy(tail[bytes-len(num):], num)
+
+		// CBC-MAC of P || Q with a zero IV.
+		c.block.Encrypt(mac, p)
+		for j := 0; j < len(q); j += 16 {
+			for k := 0; k < 16; k++ {
+				mac[k] ^= q[j+k]
+			}
+			c.block.Encrypt(mac, mac)
+		}
+		copy(s, mac)
+		for j := 1; j*16 < d; j++ {
+			block := s[j*16 : j*16+16]
+			copy(block, mac)
+			binary.BigEndian.PutUint32(block[12:], binary.BigEndian.Uint32(mac[12:])^uint32(j))
+			c.block.Encrypt(block, block)
+		}
+		y := new(big.Int).SetBytes(s[:d])
+
+		m := u
+		if i%2 == 1 {
+			m = v
+		}
+		if encrypt {
+			a, b = b, fpeStr(radix, m, new(big.Int).Add(fpeNum(radix, a), y))
+		} else {
+			a, b = fpeStr(radix, m, new(big.Int).Sub(fpeNum(radix, b), y)), a
+		}
+	}
+	return newFromIndexes(c.values, append(append([]int(nil), a...), b...)), nil
+}
+
+// FF3 is the FF3-1 format preserving encryption mode of NIST SP 800-38G
+// Revision 1 over AES, which takes 56 bit tweaks. It encrypts a numeral into
+// another numeral of the same length and digit values.
+type FF3 struct {
+	block  cipher.Block
+	values []rune
+	maxLen int
+}
+
+// NewFF3 creates an FF3-1 cipher with an AES key of 16, 24 or 32 bytes for the
+// system defined by values, which must have between 2 and 65536 digit values.
+func NewFF3(key []byte, values []rune) (*FF3, error) {
+	// the key is used byte reversed.
+	reversed := make([]byte, len(key))
+	for i, k := range key {
+		reversed[len(key)-1-i] = k
+	}
+	block, err := newFPEBlock(reversed, values)
+	if err != nil {
+		return nil, err
+	}
+	// each half must fit in 96 bits.
+	limit := new(big.Int).Lsh(big.NewInt(1), 96)
+	half := 0
+	radix := big.NewInt(int64(len(values)))
+	for p := new(big.Int).Set(radix); p.Cmp(limit) <= 0; p.Mul(p, radix) {
+		half++
+	}
+	return &FF3{block: block, values: values, maxLen: 2 * half}, nil
+}
+
+// Encrypt encrypts the Numeral n with a 7 byte tweak.
+func (c *FF3) Encrypt(n Numeral, tweak []byte) (*Numeral, error) {
+	return c.crypt(n, tweak, true)
+}
+
+// Decrypt decrypts the Numeral n that was encrypted with tweak.
+func (c *FF3) Decrypt(n Numeral, tweak []byte) (*Numeral, error) {
+	return c.crypt(n, tweak, false)
+}
+
+// crypt runs the 8 Feistel rounds of FF3-1 forwards or backwards.
+func (c *FF3) crypt(n Numeral, tweak []byte, encrypt bool) (*Numeral, error) {
+	if len(tweak) != 7 {
+		return nil, fmt.Errorf("numeral: invalid FF3-1 tweak length %d, must be 7 bytes", len(tweak))
+	}
+	radix := len(c.values)
+	x, err := fpeIndexes(n, c.values, int64(c.maxLen))
+	if err != nil {
+		return nil, err
+	}
+	length := len(x)
+	v := length / 2
+	u := length - v
+	a, b := x[:u], x[u:]
+
+	// the tweak is split in two 32 bit halves sharing its middle nibble.
+	left := []byte{tweak[0], tweak[1], tweak[2], tweak[3] & 0xf0}
+	right := []byte{tweak[4], tweak[5], tweak[6], tweak[3] << 4}
+
+	p := make([]byte, 16)
+	for round := 0; round < 8; round++ {
+		i := round
+		if !encrypt {
+			i = 7 - round
+		}
+		m, w := u, right
+		if i%2 == 1 {
+			m, w = v, left
+		}
+		in := b
+		if !encrypt {
+			in = a
+		}
+		copy(p, w)
+		p[3] ^= byte(i)
+		for j := 4; j < 16; j++ {
+			p[j] = 0
+		}
+		num := fpeNum(radix, reverseIndexes(in)).Bytes()
+		copy(p[16-len(num):], num)
+
+		// the block is encrypted byte reversed.
+		reverseBytes(p)
+		c.block.Encrypt(p, p)
+		reverseBytes(p)
+		y := new(big.Int).SetBytes(p)
+
+		if encrypt {
+			sum := new(big.Int).Add(fpeNum(radix, reverseIndexes(a)), y)
+			a, b = b, reverseIndexes(fpeStr(radix, m, sum))
+		} else {
+			diff := new(big.Int).Sub(fpeNum(radix, reverseIndexes(b)), y)
+			a, b = reverseIndexes(fpeStr(radix, m, diff)), a
+		}
+	}
+	return newFromIndexes(c.values, append(append([]int(nil), a...), b...)), nil
+}
+
+// newFPEBlock validates the digit values of a format preserving cipher and
+// creates its AES block cipher.
+func newFPEBlock(key []byte, values []rune) (cipher.Block, error) {
+	if len(values) < 2 || len(values) > 1<<16 {
+		return nil, fmt.Errorf("numeral: invalid amount of digit values for format preserving encryption: %d", len(values))
+	}
+	return aes.NewCipher(key)
+}
+
+// fpeIndexes returns the digit positions of n, checking that it uses values
+// and that its length is between the one that allows at least a million
+// distinct numerals and maxLen.
+func fpeIndexes(n Numeral, values []rune, maxLen int64) ([]int, error) {
+	if !sameValues(n.digitValues, values) {
+		return nil, fmt.Errorf("numeral: numeral and cipher use different digit values")
+	}
+	x := n.digitIndexes()
+	minLen := 1
+	for p := len(values); p < 1000000; p *= len(values) {
+		minLen++
+	}
+	if len(x) < minLen || int64(len(x)) > maxLen {
+		return nil, fmt.Errorf("numeral: invalid numeral length %d, must be between %d and %d digits", len(x), minLen, maxLen)
+	}
+	return x, nil
+}
+
+// fpeNum returns the integer whose base radix digits are indexes.
+func fpeNum(radix int, indexes []int) *big.Int {
+	b := big.NewInt(int64(radix))
+	x := new(big.Int)
+	for _, d := range indexes {
+		x.Mul(x, b)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	return x
+}
+
+// fpeStr returns the m base radix digits of x modulo radix^m.
+func fpeStr(radix, m int, x *big.Int) []int {
+	modulus := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(m)), nil)
+	x.Mod(x, modulus)
+	return padIndexes(bigIndexes(radix, x), m)
+}
+
+// ceilLog2Pow returns the smallest integer that is not less than v * log2(radix).
+func ceilLog2Pow(radix, v int) int {
+	return new(big.Int).Sub(new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(v)), nil), big.NewInt(1)).BitLen()
+}
+
+// reverseIndexes returns a reversed copy of indexes.
+func reverseIndexes(indexes []int) []int {
+	reversed := make([]int, len(indexes))
+	for i, d := range indexes {
+		reversed[len(indexes)-1-i] = d
+	}
+	return reversed
+}
+
+// reverseBytes reverses b in place.
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
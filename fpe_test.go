@@ -0,0 +1,128 @@
+package numeral_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+var base36Values = []rune("0123456789abcdefghijklmnopqrstuvwxyz")
+
+// NIST SP 800-38G FF1 samples.
+var ff1Tests = []struct {
+	key    string
+	tweak  string
+	values []rune
+	plain  string
+	cipher string
+}{
+	{"2b7e151628aed2a6abf7158809cf4f3c", "", decimalValues, "0123456789", "2433477484"},
+	{"2b7e151628aed2a6abf7158809cf4f3c", "39383736353433323130", decimalValues, "0123456789", "6124200773"},
+	{"2b7e151628aed2a6abf7158809cf4f3c", "3737373770717273373737", base36Values, "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f", "", decimalValues, "0123456789", "2830668132"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f", "39383736353433323130", decimalValues, "0123456789", "2496655549"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f", "3737373770717273373737", base36Values, "0123456789abcdefghi", "xbj3kv35jrawxv32ysr"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f7f036d6f04fc6a94", "", decimalValues, "0123456789", "6657667009"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f7f036d6f04fc6a94", "39383736353433323130", decimalValues, "0123456789", "1001623463"},
+	{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f7f036d6f04fc6a94", "3737373770717273373737", base36Values, "0123456789abcdefghi", "xs8a0azh2avyalyzuwd"},
+}
+
+func TestFF1(t *testing.T) {
+	for _, tt := range ff1Tests {
+		t.Run(tt.cipher, func(t *testing.T) {
+			key, _ := hex.DecodeString(tt.key)
+			tweak, _ := hex.DecodeString(tt.tweak)
+			c, err := numeral.NewFF1(key, tt.values)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			testFPE(t, c, tt.values, tweak, tt.plain, tt.cipher)
+		})
+	}
+}
+
+var (
+	base26Values = []rune("abcdefghijklmnopqrstuvwxyz")
+	ff3B64Values = []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz+/")
+)
+
+// FF3-1 samples of the revised standard, from the NIST ACVP test vectors.
+var ff3Tests = []struct {
+	key    string
+	tweak  string
+	values []rune
+	plain  string
+	cipher string
+}{
+	{"2de79d232df5585d68ce47882ae256d6", "cbd09280979564", decimalValues, "3992520240", "8901801106"},
+	{"01c63017111438f7fc8e24eb16c71ab5", "c4e822dcd09f27", decimalValues, "60761757463116869318437658042297305934914824457484538562", "35637144092473838892796702739628394376915177448290847293"},
+	{"718385e6542534604419e83ce387a437", "b6f35084fa90e1", base26Values, "wfmwlrorcd", "ywowehycyd"},
+	{"db602dff22ed7e84c8d8c865a941a238", "ebefd63bcc2083", base26Values, "kkuomenbzqvggfbteqdyanwpmhzdmoicekiihkrm", "belcfahcwwytwrckieymthabgjjfkxtxauipmjja"},
+	{"aee87d0d485b3afd12bd1e0b9d03d50d", "5f9140601d224b", ff3B64Values, "ixvuuIHr0e", "GR90R1q838"},
+	{"f62edb777a671075d47563f3a1e9ac797aa706a2d8e02fc8", "493b8451bf6716", decimalValues, "4406616808", "1807744762"},
+	{"1faa03eff55a06f8fab3f1dc57127d493e2f8f5c365540467a3a055bdbe6481d", "4d67130c030445", decimalValues, "3679409436", "1735794859"},
+}
+
+func TestFF3(t *testing.T) {
+	for _, tt := range ff3Tests {
+		t.Run(tt.cipher, func(t *testing.T) {
+			key, _ := hex.DecodeString(tt.key)
+			tweak, _ := hex.DecodeString(tt.tweak)
+			c, err := numeral.NewFF3(key, tt.values)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			testFPE(t, c, tt.values, tweak, tt.plain, tt.cipher)
+		})
+	}
+}
+
+type fpeCipher interface {
+	Encrypt(n numeral.Numeral, tweak []byte) (*numeral.Numeral, error)
+	Decrypt(n numeral.Numeral, tweak []byte) (*numeral.Numeral, error)
+}
+
+func testFPE(t *testing.T, c fpeCipher, values []rune, tweak []byte, plain, cipher string) {
+	t.Helper()
+	p, _ := numeral.NewNumeral(values, plain)
+	got, err := c.Encrypt(*p, tweak)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got.String() != cipher {
+		t.Errorf("got: %s want: %s", got.String(), cipher)
+	}
+	back, err := c.Decrypt(*got, tweak)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if back.String() != plain {
+		t.Errorf("got: %s want: %s", back.String(), plain)
+	}
+}
+
+func TestFPEInvalid(t *testing.T) {
+	key := make([]byte, 16)
+	ff1, _ := numeral.NewFF1(key, decimalValues)
+	short, _ := numeral.NewNumeral(decimalValues, "12345")
+	if _, err := ff1.Encrypt(*short, nil); err == nil {
+		t.Error("expected error to be thrown on a numeral with less than a million values")
+	}
+	other, _ := numeral.NewNumeral(hexValues, "0123456789")
+	if _, err := ff1.Encrypt(*other, nil); err == nil {
+		t.Error("expected error to be thrown on different digit values")
+	}
+	ff3, _ := numeral.NewFF3(key, decimalValues)
+	p, _ := numeral.NewNumeral(decimalValues, "0123456789")
+	if _, err := ff3.Encrypt(*p, make([]byte, 8)); err == nil {
+		t.Error("expected error to be thrown on a 64 bit tweak")
+	}
+	long, _ := numeral.NewNumeral(decimalValues, "012345678901234567890123456789012345678901234567890123456789")
+	if _, err := ff3.Encrypt(*long, make([]byte, 7)); err == nil {
+		t.Error("expected error to be thrown on a too long numeral")
+	}
+	if _, err := numeral.NewFF1(make([]byte, 15), decimalValues); err == nil {
+		t.Error("expected error to be thrown on an invalid key length")
+	}
+}
@@ -0,0 +1,181 @@
+package numeral
+
+import "fmt"
+
+// galoisField is the finite field GF(p^m). Its elements are the integers
+// [0, p^m) whose base p digits are the coefficients of a polynomial over
+// GF(p), so they map one to one to digit positions of a system with p^m
+// digit values.
+type galoisField struct {
+	q, p, m int
+	exp     []int
+	log     []int
+}
+
+// newGaloisField builds the field of order q, which must be a prime power, out
+// of the first primitive polynomial of degree m over GF(p).
+func newGaloisField(q int) (*galoisField, error) {
+	p, m, ok := primePower(q)
+	if !ok {
+		return nil, fmt.Errorf("numeral: %d is not a prime power", q)
+	}
+	f := galoisField{
+		q:   q,
+		p:   p,
+		m:   m,
+		exp: make([]int, 2*(q-1)),
+		log: make([]int, q),
+	}
+	// try every monic polynomial of degree m until x generates all non zero
+	// elements.
+	for c := 1; c < q; c++ {
+		if f.buildTables(c) {
+			return &f, nil
+		}
+	}
+	return nil, fmt.Errorf("numeral: no primitive polynomial found for GF(%d)", q)
+}
+
+// primePower splits q into p^m.
+func primePower(q int) (p, m int, ok bool) {
+	if q < 2 {
+		return 0, 0, false
+	}
+	p = 2
+	for q%p != 0 {
+		p++
+	}
+	for q%p == 0 {
+		q /= p
+		m++
+	}
+	return p, m, q == 1
+}
+
+// buildTables fills the exp and log tables using x^m + poly as the field
+// polynomial, where poly holds the lower coefficients as base p digits. It
+// reports whether the polynomial is primitive.
+func (f *galoisField) buildTables(poly int) bool {
+	coefficients := f.digits(poly)
+	seen := make([]bool, f.q)
+	e := make([]int, f.m)
+	e[0] = 1
+	for i := 0; i < f.q-1; i++ {
+		v := f.value(e)
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+		f.exp[i] = v
+		f.exp[i+f.q-1] = v
+		f.log[v] = i
+		// multiply by x and reduce with the field polynomial.
+		top := e[f.m-1]
+		copy(e[1:], e[:f.m-1])
+		e[0] = 0
+		for j := range e {
+			e[j] = ((e[j]-top*coefficients[j])%f.p + f.p) % f.p
+		}
+	}
+	return f.value(e) == 1
+}
+
+// digits returns the base p digits of a, least significant first.
+func (f *galoisField) digits(a int) []int {
+	d := make([]int, f.m)
+	for i := range d {
+		d[i] = a % f.p
+		a /= f.p
+	}
+	return d
+}
+
+// value is the inverse of digits.
+func (f *galoisField) value(d []int) int {
+	v := 0
+	for i := len(d) - 1; i >= 0; i-- {
+		v = v*f.p + d[i]
+	}
+	return v
+}
+
+// add returns a + b.
+func (f *galoisField) add(a, b int) int {
+	if f.p == 2 {
+		return a ^ b
+	}
+	v, pow := 0, 1
+	for a > 0 || b > 0 {
+		v += (a%f.p + b%f.p) % f.p * pow
+		a, b, pow = a/f.p, b/f.p, pow*f.p
+	}
+	return v
+}
+
+// mulInt returns a added k times to itself.
+func (f *galoisField) mulInt(a, k int) int {
+	k %= f.p
+	v, pow := 0, 1
+	for a > 0 {
+		v += a % f.p * k % f.p * pow
+		a, pow = a/f.p, pow*f.p
+	}
+	return v
+}
+
+// neg returns -a.
+func (f *galoisField) neg(a int) int {
+	return f.mulInt(a, f.p-1)
+}
+
+// sub returns a - b.
+func (f *galoisField) sub(a, b int) int {
+	return f.add(a, f.neg(b))
+}
+
+// mul returns a * b.
+func (f *galoisField) mul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return f.exp[f.log[a]+f.log[b]]
+}
+
+// inv returns the multiplicative inverse of a non zero a.
+func (f *galoisField) inv(a int) int {
+	return f.exp[(f.q-1-f.log[a])%(f.q-1)]
+}
+
+// div returns a / b for a non zero b.
+func (f *galoisField) div(a, b int) int {
+	return f.mul(a, f.inv(b))
+}
+
+// alphaPow returns the primitive element raised to e, which can be negative.
+func (f *galoisField) alphaPow(e int) int {
+	e %= f.q - 1
+	if e < 0 {
+		e += f.q - 1
+	}
+	return f.exp[e]
+}
+
+// polyEval evaluates a polynomial, lowest coefficient first, at x.
+func (f *galoisField) polyEval(poly []int, x int) int {
+	v := 0
+	for i := len(poly) - 1; i >= 0; i-- {
+		v = f.add(f.mul(v, x), poly[i])
+	}
+	return v
+}
+
+// polyMul returns the product of two polynomials.
+func (f *galoisField) polyMul(a, b []int) []int {
+	product := make([]int, len(a)+len(b)-1)
+	for i, x := range a {
+		for j, y := range b {
+			product[i+j] = f.add(product[i+j], f.mul(x, y))
+		}
+	}
+	return product
+}
@@ -0,0 +1,99 @@
+package numeral
+
+import "container/ring"
+
+// ToGray returns the reflected Gray code of the Numeral in the same system and
+// with the same amount of digits. Numerals that are next to each other in
+// counting order have Gray codes that differ in exactly one digit by ±1.
+func (n *Numeral) ToGray() *Numeral {
+	last := len(n.digitValues) - 1
+	indexes := n.digitIndexes()
+	parity := 0
+	for i, a := range indexes {
+		// a digit is reflected when the digits on its left sum up to an odd value.
+		if parity%2 == 1 {
+			indexes[i] = last - a
+		}
+		parity += indexes[i]
+	}
+	return newFromIndexes(n.digitValues, indexes)
+}
+
+// FromGray converts a reflected Gray code back to the Numeral it encodes. It
+// is the inverse of ToGray.
+func (n *Numeral) FromGray() *Numeral {
+	last := len(n.digitValues) - 1
+	indexes := n.digitIndexes()
+	parity := 0
+	for i, g := range indexes {
+		if parity%2 == 1 {
+			indexes[i] = last - g
+		}
+		parity += g
+	}
+	return newFromIndexes(n.digitValues, indexes)
+}
+
+// GrayIterator walks over all numerals of a fixed amount of digits in
+// reflected Gray code order, the single change counterpart of Increment.
+// Every step rotates exactly one digit by one position.
+type GrayIterator struct {
+	current    *Numeral
+	directions []int
+	started    bool
+	done       bool
+}
+
+// NewGrayIterator creates a Gray code iterator whose first value is start. The
+// iteration ends after the last Gray code with as many digits as start, so
+// starting from all zeros visits every numeral of that length exactly once.
+func NewGrayIterator(start Numeral) *GrayIterator {
+	current := start.clone()
+	// every digit moves upwards when the digits on its left sum up to an even value.
+	directions := make([]int, current.digits.Len())
+	parity := 0
+	for i, g := range current.digitIndexes() {
+		directions[i] = 1
+		if parity%2 == 1 {
+			directions[i] = -1
+		}
+		parity += g
+	}
+	return &GrayIterator{
+		current:    current,
+		directions: directions,
+	}
+}
+
+// Next advances the iterator to the next Gray code. It returns false when
+// there are no more codes of that length.
+func (it *GrayIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		return true
+	}
+	last := len(it.current.digitValues) - 1
+	// take the first digit from the right that can move towards its direction,
+	// reversing the direction of all the digits that can not.
+	i := len(it.directions) - 1
+	for e := it.current.digits.Back(); e != nil; e = e.Prev() {
+		r := e.Value.(*ring.Ring)
+		next := indexOf(r.Value.(rune), it.current.digitValues) + it.directions[i]
+		if next >= 0 && next <= last {
+			e.Value = r.Move(it.directions[i])
+			return true
+		}
+		it.directions[i] = -it.directions[i]
+		i--
+	}
+	it.done = true
+	return false
+}
+
+// Numeral returns a copy of the Gray code the iterator is currently at.
+func (it *GrayIterator) Numeral() *Numeral {
+	return it.current.clone()
+}
@@ -0,0 +1,83 @@
+package numeral_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestToGray(t *testing.T) {
+	ternaryValues := []rune{'0', '1', '2'}
+	grayTests := []struct {
+		values []rune
+		number string
+		want   string
+	}{
+		{[]rune{'0', '1'}, "0000", "0000"},
+		{[]rune{'0', '1'}, "0111", "0100"},
+		{[]rune{'0', '1'}, "1000", "1100"},
+		{ternaryValues, "02", "02"},
+		{ternaryValues, "10", "12"},
+		{ternaryValues, "12", "10"},
+		{ternaryValues, "20", "20"},
+	}
+	for _, tt := range grayTests {
+		t.Run(tt.number, func(t *testing.T) {
+			number, _ := numeral.NewNumeral(tt.values, tt.number)
+			gray := number.ToGray()
+			if got, want := gray.String(), tt.want; got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+			if got, want := gray.FromGray().String(), tt.number; got != want {
+				t.Errorf("FromGray got: %s want: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestGrayIterator(t *testing.T) {
+	ternaryValues := []rune{'0', '1', '2'}
+	start, _ := numeral.NewNumeral(ternaryValues, "00")
+	it := numeral.NewGrayIterator(*start)
+	var got []string
+	for it.Next() {
+		got = append(got, it.Numeral().String())
+	}
+	want := []string{"00", "01", "02", "12", "11", "10", "20", "21", "22"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestGrayIteratorMatchesToGray(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "000")
+	counter, _ := numeral.NewNumeral(testValues, "000")
+	it := numeral.NewGrayIterator(*start)
+	count := 0
+	var previous []rune
+	for it.Next() {
+		gray := it.Numeral()
+		if got, want := gray.String(), counter.ToGray().String(); got != want {
+			t.Fatalf("at %s got: %s want: %s", counter.String(), got, want)
+		}
+		current := []rune(gray.String())
+		if previous != nil {
+			changes := 0
+			for i := range current {
+				if current[i] != previous[i] {
+					changes++
+				}
+			}
+			if changes != 1 {
+				t.Fatalf("expected a single digit change between %s and %s", string(previous), string(current))
+			}
+		}
+		previous = current
+		counter.Increment()
+		count++
+	}
+	if want := len(testValues) * len(testValues) * len(testValues); count != want {
+		t.Errorf("got: %d codes want: %d", count, want)
+	}
+}
@@ -0,0 +1,327 @@
+package numeral
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// Base62Values are the digit values of base62, digits first, then upper and
+// lower case letters.
+var Base62Values = []rune("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+// GeneratorOption configures an ID generator.
+type GeneratorOption func(*generatorConfig)
+
+// generatorConfig holds the settings shared by the ID generators.
+type generatorConfig struct {
+	clock   func() time.Time
+	entropy io.Reader
+	values  []rune
+	epoch   time.Time
+}
+
+// WithClock makes the generator read the time from clock instead of the system
+// clock, e.g. to get reproducible IDs in tests.
+func WithClock(clock func() time.Time) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.clock = clock
+	}
+}
+
+// WithEntropy makes the generator read its randomness from r instead of
+// crypto/rand.
+func WithEntropy(r io.Reader) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.entropy = r
+	}
+}
+
+// WithValues renders the IDs with values instead of the default digit values
+// of the generator.
+func WithValues(values []rune) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.values = values
+	}
+}
+
+// WithEpoch sets the epoch the timestamps of Snowflake IDs count from.
+func WithEpoch(epoch time.Time) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.epoch = epoch
+	}
+}
+
+// newGeneratorConfig applies opts over the defaults of a generator.
+func newGeneratorConfig(values []rune, opts []GeneratorOption) (generatorConfig, error) {
+	c := generatorConfig{
+		clock:   time.Now,
+		entropy: rand.Reader,
+		values:  values,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if len(c.values) < 2 {
+		return c, fmt.Errorf("numeral: at least 2 digit values are needed to render IDs, got: %d", len(c.values))
+	}
+	return c, nil
+}
+
+// fixedWidth returns the amount of digits of a system with the given base
+// that are needed for any n byte integer.
+func fixedWidth(base, n int) int {
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(8*n))
+	b := big.NewInt(int64(base))
+	width := 0
+	for p := big.NewInt(1); p.Cmp(limit) < 0; p.Mul(p, b) {
+		width++
+	}
+	return width
+}
+
+// encodeFixed renders b as a big endian integer with a fixed amount of digits,
+// so that the string order of the output matches the order of the bytes.
+func encodeFixed(values []rune, b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	indexes := padIndexes(bigIndexes(len(values), x), fixedWidth(len(values), len(b)))
+	return newFromIndexes(values, indexes).String()
+}
+
+// decodeFixed is the inverse of encodeFixed, writing the integer into dst.
+func decodeFixed(values []rune, s string, dst []byte) error {
+	digits := []rune(s)
+	if want := fixedWidth(len(values), len(dst)); len(digits) != want {
+		return fmt.Errorf("numeral: invalid length %d, want %d digits", len(digits), want)
+	}
+	x := new(big.Int)
+	base := big.NewInt(int64(len(values)))
+	for i, c := range digits {
+		d := indexOf(c, values)
+		if d == -1 {
+			return fmt.Errorf("numeral: invalid digit %q at position %d", c, i)
+		}
+		x.Mul(x, base)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+	if x.BitLen() > 8*len(dst) {
+		return fmt.Errorf("numeral: value of %s does not fit in %d bytes", s, len(dst))
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	b := x.Bytes()
+	copy(dst[len(dst)-len(b):], b)
+	return nil
+}
+
+// incrementBytes adds one to a big endian integer. It reports false when the
+// integer overflows.
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ULIDGenerator generates ULIDs: a 48 bit millisecond timestamp followed by 80
+// random bits, rendered in Crockford's base32 by default. IDs generated
+// within the same millisecond increment the random bits of the previous one,
+// so they are strictly increasing. It is safe for concurrent use.
+type ULIDGenerator struct {
+	mu     sync.Mutex
+	config generatorConfig
+	last   [16]byte
+	issued bool
+}
+
+// NewULIDGenerator creates a ULID generator.
+func NewULIDGenerator(opts ...GeneratorOption) (*ULIDGenerator, error) {
+	c, err := newGeneratorConfig(CrockfordValues, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ULIDGenerator{config: c}, nil
+}
+
+// Next returns a new ULID.
+func (g *ULIDGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := uint64(g.config.clock().UnixNano() / int64(time.Millisecond))
+	if ms >= 1<<48 {
+		return "", fmt.Errorf("numeral: time does not fit in a ULID")
+	}
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], ms<<16)
+	if g.issued && ms == binary.BigEndian.Uint64(g.last[:8])>>16 {
+		copy(id[6:], g.last[6:])
+		if !incrementBytes(id[6:]) {
+			return "", fmt.Errorf("numeral: ULID random bits overflow within millisecond %d", ms)
+		}
+	} else if _, err := io.ReadFull(g.config.entropy, id[6:]); err != nil {
+		return "", err
+	}
+	g.last, g.issued = id, true
+	return encodeFixed(g.config.values, id[:]), nil
+}
+
+// ParseULID returns the timestamp and the random bits of a ULID rendered with
+// values.
+func ParseULID(values []rune, s string) (time.Time, [10]byte, error) {
+	var id [16]byte
+	var entropy [10]byte
+	if err := decodeFixed(values, s, id[:]); err != nil {
+		return time.Time{}, entropy, err
+	}
+	ms := int64(binary.BigEndian.Uint64(id[:8]) >> 16)
+	copy(entropy[:], id[6:])
+	return time.Unix(0, ms*int64(time.Millisecond)), entropy, nil
+}
+
+// ksuidEpoch is the epoch of KSUID timestamps, in seconds since the Unix epoch.
+const ksuidEpoch = 1400000000
+
+// KSUIDGenerator generates KSUIDs: a 32 bit timestamp in seconds since the
+// KSUID epoch followed by a 128 bit random payload, rendered in base62 by
+// default. IDs generated within the same second increment the payload of the
+// previous one. It is safe for concurrent use.
+type KSUIDGenerator struct {
+	mu     sync.Mutex
+	config generatorConfig
+	last   [20]byte
+	issued bool
+}
+
+// NewKSUIDGenerator creates a KSUID generator.
+func NewKSUIDGenerator(opts ...GeneratorOption) (*KSUIDGenerator, error) {
+	c, err := newGeneratorConfig(Base62Values, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &KSUIDGenerator{config: c}, nil
+}
+
+// Next returns a new KSUID.
+func (g *KSUIDGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	seconds := g.config.clock().Unix() - ksuidEpoch
+	if seconds < 0 || seconds >= 1<<32 {
+		return "", fmt.Errorf("numeral: time does not fit in a KSUID")
+	}
+	var id [20]byte
+	binary.BigEndian.PutUint32(id[:4], uint32(seconds))
+	if g.issued && uint32(seconds) == binary.BigEndian.Uint32(g.last[:4]) {
+		copy(id[4:], g.last[4:])
+		if !incrementBytes(id[4:]) {
+			return "", fmt.Errorf("numeral: KSUID payload overflow within second %d", seconds)
+		}
+	} else if _, err := io.ReadFull(g.config.entropy, id[4:]); err != nil {
+		return "", err
+	}
+	g.last, g.issued = id, true
+	return encodeFixed(g.config.values, id[:]), nil
+}
+
+// ParseKSUID returns the timestamp and the payload of a KSUID rendered with
+// values.
+func ParseKSUID(values []rune, s string) (time.Time, [16]byte, error) {
+	var id [20]byte
+	var payload [16]byte
+	if err := decodeFixed(values, s, id[:]); err != nil {
+		return time.Time{}, payload, err
+	}
+	copy(payload[:], id[4:])
+	return time.Unix(int64(binary.BigEndian.Uint32(id[:4]))+ksuidEpoch, 0), payload, nil
+}
+
+// Bits of the parts of a Snowflake ID.
+const (
+	snowflakeTimeBits     = 41
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+)
+
+// SnowflakeEpoch is the default epoch of Snowflake IDs, the one of Twitter.
+var SnowflakeEpoch = time.Unix(0, 1288834974657*int64(time.Millisecond))
+
+// SnowflakeGenerator generates Snowflake IDs: a 41 bit millisecond timestamp
+// since an epoch, a 10 bit node and a 12 bit sequence within the millisecond.
+// IDs are rendered in decimal by default, zero padded so that they sort
+// lexicographically. When the sequence of a millisecond runs out, the next
+// millisecond is borrowed instead of waiting for the clock. It is safe for
+// concurrent use.
+type SnowflakeGenerator struct {
+	mu       sync.Mutex
+	config   generatorConfig
+	node     uint64
+	lastMs   int64
+	sequence uint64
+}
+
+// NewSnowflakeGenerator creates a Snowflake ID generator for node, which must
+// be between 0 and 1023.
+func NewSnowflakeGenerator(node int, opts ...GeneratorOption) (*SnowflakeGenerator, error) {
+	if node < 0 || node >= 1<<snowflakeNodeBits {
+		return nil, fmt.Errorf("numeral: invalid Snowflake node: %d", node)
+	}
+	c, err := newGeneratorConfig([]rune("0123456789"), append([]GeneratorOption{WithEpoch(SnowflakeEpoch)}, opts...))
+	if err != nil {
+		return nil, err
+	}
+	return &SnowflakeGenerator{
+		config: c,
+		node:   uint64(node),
+		lastMs: -1,
+	}, nil
+}
+
+// Next returns a new Snowflake ID.
+func (g *SnowflakeGenerator) Next() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := g.config.clock().Sub(g.config.epoch).Nanoseconds() / int64(time.Millisecond)
+	if ms < 0 {
+		return "", fmt.Errorf("numeral: time before the Snowflake epoch")
+	}
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.sequence++
+		if g.sequence == 1<<snowflakeSequenceBits {
+			ms++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+	if ms >= 1<<snowflakeTimeBits {
+		return "", fmt.Errorf("numeral: time does not fit in a Snowflake ID")
+	}
+	g.lastMs = ms
+	id := uint64(ms)<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], id)
+	return encodeFixed(g.config.values, b[:]), nil
+}
+
+// ParseSnowflake returns the timestamp, the node and the sequence of a
+// Snowflake ID rendered with values and counting from epoch.
+func ParseSnowflake(values []rune, s string, epoch time.Time) (t time.Time, node int, sequence int, err error) {
+	var b [8]byte
+	if err := decodeFixed(values, s, b[:]); err != nil {
+		return time.Time{}, 0, 0, err
+	}
+	id := binary.BigEndian.Uint64(b[:])
+	ms := int64(id >> (snowflakeNodeBits + snowflakeSequenceBits))
+	node = int(id >> snowflakeSequenceBits & (1<<snowflakeNodeBits - 1))
+	sequence = int(id & (1<<snowflakeSequenceBits - 1))
+	return epoch.Add(time.Duration(ms) * time.Millisecond), node, sequence, nil
+}
@@ -0,0 +1,146 @@
+package numeral_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/slysterous/numeral"
+)
+
+// fakeClock returns a clock that always returns t.
+func fakeClock(t *time.Time) func() time.Time {
+	return func() time.Time {
+		return *t
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	ts, _, err := numeral.ParseULID(numeral.CrockfordValues, "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, want := ts.UnixNano()/int64(time.Millisecond), int64(1469922850259); got != want {
+		t.Errorf("got: %d want: %d", got, want)
+	}
+}
+
+func TestULIDGeneratorIsMonotonic(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	entropy := bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))
+	g, err := numeral.NewULIDGenerator(numeral.WithClock(fakeClock(&now)), numeral.WithEntropy(entropy))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	first, _ := g.Next()
+	// the random bits are all ones, so the next ULID of the same millisecond overflows.
+	if _, err := g.Next(); err == nil {
+		t.Errorf("expected error to be thrown on Next")
+	}
+	now = now.Add(time.Millisecond)
+	g, _ = numeral.NewULIDGenerator(numeral.WithClock(fakeClock(&now)), numeral.WithEntropy(bytes.NewReader(make([]byte, 10))))
+	ids := []string{first}
+	for i := 0; i < 100; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if len(id) != 26 {
+			t.Fatalf("got: %d digits want: 26", len(id))
+		}
+		ids = append(ids, id)
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("expected ULIDs to be sorted: %v", ids)
+	}
+	ts, entropyBits, err := numeral.ParseULID(numeral.CrockfordValues, ids[100])
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if !ts.Equal(now) || entropyBits[9] != 99 {
+		t.Errorf("got: %v %x want: %v ...63", ts, entropyBits, now)
+	}
+}
+
+func TestKSUID(t *testing.T) {
+	ts, payload, err := numeral.ParseKSUID(numeral.Base62Values, "0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, want := ts.Unix(), int64(1507608047); got != want {
+		t.Errorf("got: %d want: %d", got, want)
+	}
+	if got, want := hex.EncodeToString(payload[:]), "b5a1cd34b5f99d1154fb6853345c9735"; got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+	now := ts
+	g, _ := numeral.NewKSUIDGenerator(numeral.WithClock(fakeClock(&now)), numeral.WithEntropy(bytes.NewReader(payload[:])))
+	id, err := g.Next()
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if id != "0ujtsYcgvSTl8PAuAdqWYSMnLOv" {
+		t.Errorf("got: %s want: 0ujtsYcgvSTl8PAuAdqWYSMnLOv", id)
+	}
+	next, _ := g.Next()
+	if next <= id {
+		t.Errorf("expected %s to sort after %s", next, id)
+	}
+}
+
+func TestFirstIDAtZeroTimeReadsEntropy(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	ulids, _ := numeral.NewULIDGenerator(numeral.WithClock(fakeClock(&epoch)), numeral.WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))))
+	id, err := ulids.Next()
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if _, entropy, _ := numeral.ParseULID(numeral.CrockfordValues, id); entropy != [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff} {
+		t.Errorf("got: %x want the random bits of the entropy", entropy)
+	}
+
+	ksuidEpoch := time.Unix(1400000000, 0)
+	ksuids, _ := numeral.NewKSUIDGenerator(numeral.WithClock(fakeClock(&ksuidEpoch)), numeral.WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))))
+	id, err = ksuids.Next()
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if _, payload, _ := numeral.ParseKSUID(numeral.Base62Values, id); payload[0] != 0xff || payload[15] != 0xff {
+		t.Errorf("got: %x want the payload of the entropy", payload)
+	}
+}
+
+func TestSnowflakeGenerator(t *testing.T) {
+	now := numeral.SnowflakeEpoch.Add(1000 * time.Millisecond)
+	g, err := numeral.NewSnowflakeGenerator(7, numeral.WithClock(fakeClock(&now)), numeral.WithValues(hexValues))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	var ids []string
+	// more IDs than the sequence can hold within a millisecond.
+	for i := 0; i < 5000; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("expected Snowflake IDs to be sorted")
+	}
+	ts, node, sequence, err := numeral.ParseSnowflake(hexValues, ids[4999], numeral.SnowflakeEpoch)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if !ts.Equal(now.Add(time.Millisecond)) || node != 7 || sequence != 4999-4096 {
+		t.Errorf("got: %v %d %d want: %v 7 %d", ts, node, sequence, now.Add(time.Millisecond), 4999-4096)
+	}
+}
+
+func TestNewSnowflakeGeneratorInvalidNodeThrowsErr(t *testing.T) {
+	if _, err := numeral.NewSnowflakeGenerator(1024); err == nil {
+		t.Errorf("expected error to be thrown on NewSnowflakeGenerator")
+	}
+}
@@ -0,0 +1,44 @@
+package atomicfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomicfile")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state")
+	for _, want := range []string{"first\n", "second"} {
+		if err := WriteFile(path, []byte(want)); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		got, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("got: %q want: %q", got, want)
+		}
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("got: %d files want only the state file", len(files))
+	}
+}
+
+func TestWriteFileMissingDirThrowsErr(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomicfile")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := WriteFile(filepath.Join(dir, "missing", "state"), []byte("x")); err == nil {
+		t.Error("expected error to be thrown on a missing directory")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+// +build !windows
+
+package atomicfile
+
+import "os"
+
+// syncDir flushes the entries of the directory at path, e.g. after a rename.
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
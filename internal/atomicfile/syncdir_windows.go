@@ -0,0 +1,7 @@
+package atomicfile
+
+// syncDir does nothing on Windows, where syncing a directory handle fails
+// with access denied, so the rename is left to the file system to persist.
+func syncDir(path string) error {
+	return nil
+}
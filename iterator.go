@@ -0,0 +1,234 @@
+package numeral
+
+import (
+	"container/ring"
+	"fmt"
+)
+
+// Step adds step to the Numeral in place. The step is added digit by digit and
+// carries are propagated only as far as they need to go, so walking a
+// keyspace with a large stride does not need to loop over Increment.
+// The step can be a numeral of any system.
+func (n *Numeral) Step(step Numeral) error {
+	indexes, err := n.stepIndexes(step)
+	if err != nil {
+		return err
+	}
+	base := len(n.digitValues)
+	carry := 0
+	e := n.digits.Back()
+	for i := len(indexes) - 1; i >= 0 || carry > 0; i-- {
+		add := carry
+		if i >= 0 {
+			add += indexes[i]
+		}
+		// the step is done once there is nothing left to add.
+		if add == 0 && i < 0 {
+			break
+		}
+		// If needed add an extra new digit on the left side.
+		if e == nil {
+			d, _ := newDigit(n.digitValues, n.digitValues[0])
+			e = n.digits.PushFront(d)
+		}
+		r := e.Value.(*ring.Ring)
+		current := indexOf(r.Value.(rune), n.digitValues)
+		sum := current + add
+		e.Value = r.Move(sum%base - current)
+		carry = sum / base
+		e = e.Prev()
+	}
+	return nil
+}
+
+// StepBack subtracts step from the Numeral in place, borrowing digit by digit.
+// Like Decrement it returns an error and leaves the Numeral untouched when
+// the result would be negative.
+func (n *Numeral) StepBack(step Numeral) error {
+	indexes, err := n.stepIndexes(step)
+	if err != nil {
+		return err
+	}
+	if compareIndexes(n.digitIndexes(), indexes) < 0 {
+		return fmt.Errorf("numeral: can not StepBack %s from %s", step.String(), n.String())
+	}
+	base := len(n.digitValues)
+	borrow := 0
+	e := n.digits.Back()
+	for i := len(indexes) - 1; e != nil && (i >= 0 || borrow > 0); i-- {
+		sub := borrow
+		if i >= 0 {
+			sub += indexes[i]
+		}
+		r := e.Value.(*ring.Ring)
+		current := indexOf(r.Value.(rune), n.digitValues)
+		diff := current - sub
+		borrow = 0
+		if diff < 0 {
+			diff += base
+			borrow = 1
+		}
+		e.Value = r.Move(diff - current)
+		e = e.Prev()
+	}
+	return nil
+}
+
+// stepIndexes returns the digit positions of step expressed in the system of
+// the Numeral.
+func (n *Numeral) stepIndexes(step Numeral) ([]int, error) {
+	if sameValues(n.digitValues, step.digitValues) {
+		return step.digitIndexes(), nil
+	}
+	converted, err := newFromBig(n.digitValues, step.bigInt())
+	if err != nil {
+		return nil, err
+	}
+	return converted.digitIndexes(), nil
+}
+
+// IteratorOption configures an Iterator.
+type IteratorOption func(*Iterator) error
+
+// WithStep makes the iterator advance by step on every iteration. The step
+// can be a numeral of any system.
+func WithStep(step Numeral) IteratorOption {
+	return func(it *Iterator) error {
+		if step.bigInt().Sign() == 0 {
+			return fmt.Errorf("numeral: iterator step can not be zero")
+		}
+		it.step = step.clone()
+		return nil
+	}
+}
+
+// WithIntStep makes the iterator advance by step on every iteration.
+func WithIntStep(step int) IteratorOption {
+	return func(it *Iterator) error {
+		if step <= 0 {
+			return fmt.Errorf("numeral: iterator step must be positive, got: %d", step)
+		}
+		s, err := NewFromDecimal(it.current.digitValues, step)
+		if err != nil {
+			return err
+		}
+		it.step = s
+		return nil
+	}
+}
+
+// WithEnd stops the iteration after end, which is inclusive. The end can be a
+// numeral of any system.
+func WithEnd(end Numeral) IteratorOption {
+	return func(it *Iterator) error {
+		indexes, err := it.current.stepIndexes(end)
+		if err != nil {
+			return err
+		}
+		it.end = indexes
+		return nil
+	}
+}
+
+// WithReverse makes the iterator walk backwards until zero, using Decrement
+// or StepBack.
+func WithReverse() IteratorOption {
+	return func(it *Iterator) error {
+		it.reverse = true
+		return nil
+	}
+}
+
+// WithFilter makes the iterator yield only the numerals for which keep
+// returns true, e.g. codes whose last digit is a valid check digit.
+func WithFilter(keep func(Numeral) bool) IteratorOption {
+	return func(it *Iterator) error {
+		it.filter = keep
+		return nil
+	}
+}
+
+// Iterator walks over numerals starting from an initial numeral, one step at
+// a time.
+//
+//	it, _ := numeral.NewIterator(*start, numeral.WithIntStep(7))
+//	for it.Next() {
+//	    fmt.Println(it.Numeral().String())
+//	}
+type Iterator struct {
+	current *Numeral
+	step    *Numeral
+	end     []int
+	reverse bool
+	filter  func(Numeral) bool
+	started bool
+	done    bool
+}
+
+// NewIterator creates an iterator whose first value is start. By default it
+// advances with Increment and never stops.
+func NewIterator(start Numeral, opts ...IteratorOption) (*Iterator, error) {
+	it := Iterator{
+		current: start.clone(),
+	}
+	for _, opt := range opts {
+		if err := opt(&it); err != nil {
+			return nil, err
+		}
+	}
+	return &it, nil
+}
+
+// Next advances the iterator to the next numeral. It returns false when the
+// iteration is over.
+func (it *Iterator) Next() bool {
+	for !it.done {
+		if it.started {
+			if err := it.advance(); err != nil {
+				it.done = true
+				return false
+			}
+		}
+		it.started = true
+		if it.pastEnd() {
+			it.done = true
+			return false
+		}
+		if it.filter == nil || it.filter(*it.current) {
+			return true
+		}
+	}
+	return false
+}
+
+// Numeral returns a copy of the numeral the iterator is currently at.
+func (it *Iterator) Numeral() *Numeral {
+	return it.current.clone()
+}
+
+// advance moves the current numeral a single step towards the iteration
+// direction.
+func (it *Iterator) advance() error {
+	switch {
+	case it.reverse && it.step == nil:
+		return it.current.Decrement()
+	case it.reverse:
+		return it.current.StepBack(*it.step)
+	case it.step == nil:
+		return it.current.Increment()
+	default:
+		return it.current.Step(*it.step)
+	}
+}
+
+// pastEnd reports whether the current numeral went beyond the end.
+func (it *Iterator) pastEnd() bool {
+	if it.end == nil {
+		return false
+	}
+	c := compareIndexes(it.current.digitIndexes(), it.end)
+	if it.reverse {
+		return c < 0
+	}
+	return c > 0
+}
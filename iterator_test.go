@@ -0,0 +1,125 @@
+package numeral_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestStep(t *testing.T) {
+	stepTests := []struct {
+		number string
+		step   string
+		want   string
+	}{
+		{"0", "7", "7"},
+		{"z", "1", "10"},
+		{"zz", "zz", "1zy"},
+		{"0zzz", "1", "1000"},
+		{"12", "100", "112"},
+	}
+	for _, tt := range stepTests {
+		t.Run(tt.number+"+"+tt.step, func(t *testing.T) {
+			number, _ := numeral.NewNumeral(testValues, tt.number)
+			step, _ := numeral.NewNumeral(testValues, tt.step)
+			if err := number.Step(*step); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got, want := number.String(), tt.want; got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestStepFromOtherSystem(t *testing.T) {
+	number, _ := numeral.NewNumeral(testValues, "10")
+	step, _ := numeral.NewNumeral([]rune{'0', '1'}, "1001")
+	if err := number.Step(*step); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got, want := number.String(), "19"; got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+}
+
+func TestStepBack(t *testing.T) {
+	stepBackTests := []struct {
+		number string
+		step   string
+		want   string
+	}{
+		{"7", "7", "0"},
+		{"10", "1", "0z"},
+		{"1zy", "zz", "0zz"},
+		{"112", "100", "012"},
+	}
+	for _, tt := range stepBackTests {
+		t.Run(tt.number+"-"+tt.step, func(t *testing.T) {
+			number, _ := numeral.NewNumeral(testValues, tt.number)
+			step, _ := numeral.NewNumeral(testValues, tt.step)
+			if err := number.StepBack(*step); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got, want := number.String(), tt.want; got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestStepBackBelowZeroThrowsErr(t *testing.T) {
+	number, _ := numeral.NewNumeral(testValues, "5")
+	step, _ := numeral.NewNumeral(testValues, "6")
+	if err := number.StepBack(*step); err == nil {
+		t.Errorf("expected error to be thrown on StepBack")
+	}
+	if number.String() != "5" {
+		t.Errorf("expected: 5, got: %s ", number.String())
+	}
+}
+
+func TestIterator(t *testing.T) {
+	decimalValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
+	start, _ := numeral.NewNumeral(decimalValues, "3")
+	end, _ := numeral.NewNumeral(decimalValues, "30")
+	hexStep, _ := numeral.NewNumeral([]rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}, "a")
+	iteratorTests := []struct {
+		name string
+		opts []numeral.IteratorOption
+		want []string
+	}{
+		{"increment", []numeral.IteratorOption{numeral.WithEnd(*start)}, []string{"3"}},
+		{"int step", []numeral.IteratorOption{numeral.WithIntStep(7), numeral.WithEnd(*end)}, []string{"3", "10", "17", "24"}},
+		{"numeral step", []numeral.IteratorOption{numeral.WithStep(*hexStep), numeral.WithEnd(*end)}, []string{"3", "13", "23"}},
+		{"reverse", []numeral.IteratorOption{numeral.WithReverse()}, []string{"3", "2", "1", "0"}},
+		{"reverse step", []numeral.IteratorOption{numeral.WithReverse(), numeral.WithIntStep(2)}, []string{"3", "1"}},
+		{"filter", []numeral.IteratorOption{
+			numeral.WithEnd(*end),
+			numeral.WithFilter(func(n numeral.Numeral) bool { return n.Decimal()%5 == 0 }),
+		}, []string{"5", "10", "15", "20", "25", "30"}},
+	}
+	for _, tt := range iteratorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := numeral.NewIterator(*start, tt.opts...)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			var got []string
+			for it.Next() {
+				got = append(got, it.Numeral().String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: %v want: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIteratorInvalidStep(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "0")
+	if _, err := numeral.NewIterator(*start, numeral.WithIntStep(0)); err == nil {
+		t.Errorf("expected error to be thrown on NewIterator")
+	}
+}
@@ -0,0 +1,172 @@
+package numeral
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// NecklaceIterator walks, in lexicographic order, over the numerals of a fixed
+// amount of digits that are the smallest among all of their rotations. Every
+// class of numerals that are equal up to rotation is visited exactly once.
+type NecklaceIterator struct {
+	values  []rune
+	n       int
+	lyndon  bool
+	words   prenecklace
+	started bool
+	done    bool
+}
+
+// prenecklace walks, in lexicographic order, over the prefixes of necklaces of
+// n digits over k values using the FKM algorithm. It keeps the length p of the
+// longest Lyndon prefix of the current one, which is a necklace when p divides
+// n and a Lyndon word when p equals n.
+type prenecklace struct {
+	k int
+	a []int
+	p int
+}
+
+// newPrenecklace starts at the smallest prenecklace, n zero digits.
+func newPrenecklace(k, n int) prenecklace {
+	return prenecklace{k: k, a: make([]int, n), p: 1}
+}
+
+// next moves to the next prenecklace. It returns false after the last one.
+func (w *prenecklace) next() bool {
+	i := len(w.a) - 1
+	for i >= 0 && w.a[i] == w.k-1 {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	w.a[i]++
+	w.p = i + 1
+	for j := i + 1; j < len(w.a); j++ {
+		w.a[j] = w.a[j-w.p]
+	}
+	return true
+}
+
+// NewNecklaceIterator creates an iterator over the necklaces of n digits
+// under the system defined by values.
+func NewNecklaceIterator(values []rune, n int) (*NecklaceIterator, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("numeral: no digit values provided")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("numeral: invalid amount of digits: %d", n)
+	}
+	return &NecklaceIterator{
+		values: values,
+		n:      n,
+		words:  newPrenecklace(len(values), n),
+	}, nil
+}
+
+// NewLyndonIterator creates an iterator over the Lyndon words of n digits
+// under the system defined by values, which are the necklaces that are
+// strictly smaller than all of their other rotations.
+func NewLyndonIterator(values []rune, n int) (*NecklaceIterator, error) {
+	it, err := NewNecklaceIterator(values, n)
+	if err != nil {
+		return nil, err
+	}
+	it.lyndon = true
+	return it, nil
+}
+
+// Next advances the iterator to the next necklace. It returns false when
+// there are no more necklaces.
+func (it *NecklaceIterator) Next() bool {
+	for !it.done {
+		if it.started && !it.words.next() {
+			it.done = true
+			return false
+		}
+		it.started = true
+		if it.lyndon && it.words.p == it.n {
+			return true
+		}
+		if !it.lyndon && it.n%it.words.p == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Numeral returns the necklace the iterator is currently at.
+func (it *NecklaceIterator) Numeral() *Numeral {
+	return newFromIndexes(it.values, it.words.a)
+}
+
+// CountNecklaces returns the amount of necklaces of n digits under the system
+// defined by values.
+func CountNecklaces(values []rune, n int) *big.Int {
+	// (1/n) * Σ φ(d) * k^(n/d) for every divisor d of n.
+	return countRotations(values, n, totient)
+}
+
+// CountLyndonWords returns the amount of Lyndon words of n digits under the
+// system defined by values.
+func CountLyndonWords(values []rune, n int) *big.Int {
+	// (1/n) * Σ μ(d) * k^(n/d) for every divisor d of n.
+	return countRotations(values, n, mobius)
+}
+
+// countRotations sums weight(d) * k^(n/d) over the divisors d of n and divides
+// the sum by n.
+func countRotations(values []rune, n int, weight func(int) int) *big.Int {
+	if n <= 0 {
+		return new(big.Int)
+	}
+	k := big.NewInt(int64(len(values)))
+	sum := new(big.Int)
+	for d := 1; d <= n; d++ {
+		if n%d != 0 {
+			continue
+		}
+		term := new(big.Int).Exp(k, big.NewInt(int64(n/d)), nil)
+		term.Mul(term, big.NewInt(int64(weight(d))))
+		sum.Add(sum, term)
+	}
+	return sum.Quo(sum, big.NewInt(int64(n)))
+}
+
+// totient returns Euler's totient of n.
+func totient(n int) int {
+	result := n
+	for p := 2; p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+		for n%p == 0 {
+			n /= p
+		}
+		result -= result / p
+	}
+	if n > 1 {
+		result -= result / n
+	}
+	return result
+}
+
+// mobius returns the Möbius function of n.
+func mobius(n int) int {
+	result := 1
+	for p := 2; p*p <= n; p++ {
+		if n%p != 0 {
+			continue
+		}
+		n /= p
+		if n%p == 0 {
+			return 0
+		}
+		result = -result
+	}
+	if n > 1 {
+		result = -result
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package numeral_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestNecklaceIterator(t *testing.T) {
+	it, err := numeral.NewNecklaceIterator([]rune{'0', '1'}, 4)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	var got []string
+	for it.Next() {
+		got = append(got, it.Numeral().String())
+	}
+	want := []string{"0000", "0001", "0011", "0101", "0111", "1111"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestLyndonIterator(t *testing.T) {
+	it, err := numeral.NewLyndonIterator([]rune{'0', '1'}, 4)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	var got []string
+	for it.Next() {
+		got = append(got, it.Numeral().String())
+	}
+	want := []string{"0001", "0011", "0111"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestNecklaceCounts(t *testing.T) {
+	countTests := []struct {
+		values   []rune
+		n        int
+		necklace int64
+		lyndon   int64
+	}{
+		{[]rune{'0', '1'}, 4, 6, 3},
+		{[]rune{'0', '1'}, 6, 14, 9},
+		{[]rune{'0', '1', '2'}, 3, 11, 8},
+		{testValues, 5, 12093264, 12093228},
+	}
+	for _, tt := range countTests {
+		t.Run("", func(t *testing.T) {
+			if got := numeral.CountNecklaces(tt.values, tt.n); got.Int64() != tt.necklace {
+				t.Errorf("necklaces got: %v want: %d", got, tt.necklace)
+			}
+			if got := numeral.CountLyndonWords(tt.values, tt.n); got.Int64() != tt.lyndon {
+				t.Errorf("lyndon words got: %v want: %d", got, tt.lyndon)
+			}
+		})
+	}
+}
+
+func TestNecklaceIteratorMatchesCount(t *testing.T) {
+	values := []rune{'a', 'b', 'c', 'd'}
+	for n := 1; n <= 6; n++ {
+		necklaces, _ := numeral.NewNecklaceIterator(values, n)
+		lyndon, _ := numeral.NewLyndonIterator(values, n)
+		var necklaceCount, lyndonCount int64
+		for necklaces.Next() {
+			necklaceCount++
+		}
+		for lyndon.Next() {
+			lyndonCount++
+		}
+		if want := numeral.CountNecklaces(values, n).Int64(); necklaceCount != want {
+			t.Errorf("n=%d necklaces got: %d want: %d", n, necklaceCount, want)
+		}
+		if want := numeral.CountLyndonWords(values, n).Int64(); lyndonCount != want {
+			t.Errorf("n=%d lyndon words got: %d want: %d", n, lyndonCount, want)
+		}
+	}
+}
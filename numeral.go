@@ -30,6 +30,7 @@ import (
 	"container/ring"
 	"fmt"
 	"math"
+	"math/big"
 	"strings"
 )
 
@@ -41,7 +42,8 @@ type Numeral struct {
 }
 
 // NewNumeral initializes a numeral by providing the initial number in strings
-// along with the possible values that each digit can have.
+// along with the possible values that each digit can have. An invalid digit
+// is reported with a *DigitError.
 func NewNumeral(values []rune, initial string) (*Numeral, error) {
 	// initialise a new number.
 	number := Numeral{
@@ -49,16 +51,36 @@ func NewNumeral(values []rune, initial string) (*Numeral, error) {
 		digitValues: values,
 	}
 	// add digits to the number along with their state.
-	for i := 0; i < len(initial); i++ {
-		digit, err := newDigit(values, rune(initial[i]))
+	position := 0
+	for _, r := range initial {
+		if indexOf(r, values) == -1 {
+			return nil, &DigitError{Digit: r, Position: position}
+		}
+		digit, err := newDigit(values, r)
 		if err != nil {
 			return nil, err
 		}
 		number.digits.PushBack(digit)
+		position++
 	}
 	return &number, nil
 }
 
+// DigitError is returned when a numeral contains a character that is not one
+// of its digit values.
+type DigitError struct {
+	// Digit is the invalid character.
+	Digit rune
+	// Position is the position of the character in the numeral, counting
+	// characters rather than bytes from zero.
+	Position int
+}
+
+// Error implements the error interface.
+func (e *DigitError) Error() string {
+	return fmt.Sprintf("numeral: invalid digit %q at position %d", e.Digit, e.Position)
+}
+
 // newDigit creates and initializes a new digit (ring).
 func newDigit(values []rune, state rune) (*ring.Ring, error) {
 	// initialize a new empty ring
@@ -252,3 +274,122 @@ func (n Numeral) String() string {
 	}
 	return numberBytes.String()
 }
+
+// digitIndexes returns the position of every digit in the digit values,
+// starting from the most significant digit.
+func (n *Numeral) digitIndexes() []int {
+	indexes := make([]int, 0, n.digits.Len())
+	for e := n.digits.Front(); e != nil; e = e.Next() {
+		r := e.Value.(*ring.Ring)
+		indexes = append(indexes, indexOf(r.Value.(rune), n.digitValues))
+	}
+	return indexes
+}
+
+// newFromIndexes creates a numeral out of digit positions in values, starting
+// from the most significant digit.
+func newFromIndexes(values []rune, indexes []int) *Numeral {
+	number := Numeral{
+		digits:      list.New(),
+		digitValues: values,
+	}
+	for _, i := range indexes {
+		d, _ := newDigit(values, values[i])
+		number.digits.PushBack(d)
+	}
+	return &number
+}
+
+// clone returns a deep copy of the numeral so that it can be mutated
+// independently.
+func (n *Numeral) clone() *Numeral {
+	return newFromIndexes(n.digitValues, n.digitIndexes())
+}
+
+// empty reports whether the numeral has no digits, like the zero Numeral or one
+// created out of an empty string, which Increment can not advance.
+func (n *Numeral) empty() bool {
+	return n.digits == nil || n.digits.Len() == 0
+}
+
+// sameValues reports whether two sets of digit values describe the same system.
+func sameValues(values, values2 []rune) bool {
+	if len(values) != len(values2) {
+		return false
+	}
+	for i := range values {
+		if values[i] != values2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bigInt converts a numeral to an arbitrary precision integer.
+func (n *Numeral) bigInt() *big.Int {
+	base := big.NewInt(int64(len(n.digitValues)))
+	dec := new(big.Int)
+	for _, i := range n.digitIndexes() {
+		dec.Mul(dec, base)
+		dec.Add(dec, big.NewInt(int64(i)))
+	}
+	return dec
+}
+
+// bigIndexes returns the digit positions of a non negative integer in a
+// system of the given base, starting from the most significant digit.
+func bigIndexes(base int, x *big.Int) []int {
+	if x.Sign() == 0 {
+		return []int{0}
+	}
+	b := big.NewInt(int64(base))
+	q := new(big.Int).Set(x)
+	r := new(big.Int)
+	var indexes []int
+	for q.Sign() > 0 {
+		q.QuoRem(q, b, r)
+		indexes = append(indexes, int(r.Int64()))
+	}
+	// reverse so that the most significant digit comes first.
+	for i, j := 0, len(indexes)-1; i < j; i, j = i+1, j-1 {
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	}
+	return indexes
+}
+
+// newFromBig creates a numeral from an arbitrary precision integer.
+func newFromBig(values []rune, x *big.Int) (*Numeral, error) {
+	if x.Sign() < 0 {
+		return nil, fmt.Errorf("numeral: negative value %v can not be represented", x)
+	}
+	return newFromIndexes(values, bigIndexes(len(values), x)), nil
+}
+
+// compareIndexes compares two digit position sequences of the same base,
+// ignoring leading zeros. It returns -1, 0 or +1.
+func compareIndexes(a, b []int) int {
+	a, b = trimIndexes(a), trimIndexes(b)
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// trimIndexes drops the leading zero digits, keeping at least one digit.
+func trimIndexes(indexes []int) []int {
+	for len(indexes) > 1 && indexes[0] == 0 {
+		indexes = indexes[1:]
+	}
+	return indexes
+}
@@ -179,3 +179,82 @@ func BenchmarkNumeralAddSumDecOnDec(b *testing.B) {
 	num2, _ := numeral.NewFromDecimal(testValues2, 999000)
 	benchmarkNumeralSum(*num, *num2, b)
 }
+
+func BenchmarkCounterNextHexParallel(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	initValue := "0"
+	num, _ := numeral.NewNumeral(testValues, initValue)
+	c, _ := numeral.NewCounter(*num)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.Next()
+		}
+	})
+}
+
+func BenchmarkCounterReserveHexParallel(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	initValue := "0"
+	num, _ := numeral.NewNumeral(testValues, initValue)
+	c, _ := numeral.NewCounter(*num)
+	const blockSize = 1024
+	b.RunParallel(func(pb *testing.PB) {
+		var next *numeral.Numeral
+		left := 0
+		for pb.Next() {
+			if left == 0 {
+				first, _, _ := c.Reserve(blockSize)
+				next, _ = numeral.NewNumeral(testValues, first)
+				left = blockSize
+			}
+			_ = next.String()
+			next.Increment()
+			left--
+		}
+	})
+}
+
+func BenchmarkAtomicCounterNextHexSmall(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	c, err := numeral.NewAtomicCounter(testValues, 16, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for n := 0; n < b.N; n++ {
+		_, _ = c.Next()
+	}
+}
+
+func BenchmarkAtomicCounterAppendNextHexSmall(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	c, err := numeral.NewAtomicCounter(testValues, 16, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	buf := make([]byte, 0, 16)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		buf, _ = c.AppendNext(buf[:0])
+	}
+}
+
+func BenchmarkAtomicCounterNextHexParallel(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	c, _ := numeral.NewAtomicCounter(testValues, 16, 0)
+	b.RunParallel(func(pb *testing.PB) {
+		buf := make([]byte, 0, 16)
+		for pb.Next() {
+			buf, _ = c.AppendNext(buf[:0])
+		}
+	})
+}
+
+func BenchmarkAtomicCounter128AppendNextHexSmall(b *testing.B) {
+	testValues := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
+	c, _ := numeral.NewAtomicCounter128(testValues, 32, numeral.Uint128{})
+	buf := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		buf, _ = c.AppendNext(buf[:0])
+	}
+}
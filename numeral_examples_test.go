@@ -109,3 +109,12 @@ func ExampleSum() {
 
 	fmt.Printf("sum is: %s", sum.String())
 }
+
+func ExampleEncode() {
+	encoded, err := numeral.Encode(numeral.Base58BitcoinValues, []byte("simply a long string"))
+	if err != nil {
+		//handle the error
+	}
+	fmt.Println(encoded)
+	// Output: 2cFupjhnEsSn59qHXstmK2ffpLv2
+}
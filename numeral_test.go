@@ -209,6 +209,25 @@ func TestNewNumeralWrongRunes(t *testing.T) {
 	}
 }
 
+func TestNewNumeralNonASCII(t *testing.T) {
+	values := []rune("αβγδ")
+	n, err := numeral.NewNumeral(values, "βαδ")
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if n.String() != "βαδ" || n.Decimal() != 19 {
+		t.Errorf("got: %s (%d) want: βαδ (19)", n.String(), n.Decimal())
+	}
+	_, err = numeral.NewNumeral(values, "βαxδ")
+	de, ok := err.(*numeral.DigitError)
+	if !ok {
+		t.Fatalf("got: %v want a *DigitError", err)
+	}
+	if de.Digit != 'x' || de.Position != 2 {
+		t.Errorf("got: %q at %d want: 'x' at 2", de.Digit, de.Position)
+	}
+}
+
 func TestDecrementOnZeroThrowsErr(t *testing.T) {
 	number, _ := numeral.NewNumeral(testValues, "0")
 	err := number.Decrement()
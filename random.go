@@ -0,0 +1,84 @@
+package numeral
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// WithSeed makes the generator draw its randomness from a deterministic
+// stream derived from seed instead of crypto/rand, so that the same seed
+// always yields the same values. It is meant for tests and must not be used
+// for anything that has to be unpredictable.
+func WithSeed(seed uint64) GeneratorOption {
+	return func(c *generatorConfig) {
+		c.entropy = &seededReader{state: seed}
+	}
+}
+
+// seededReader is an endless stream of splitmix64 output.
+type seededReader struct {
+	state uint64
+	buf   [8]byte
+	left  int
+}
+
+// Read fills p with the next bytes of the stream.
+func (r *seededReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.left == 0 {
+			r.state += 0x9e3779b97f4a7c15
+			binary.BigEndian.PutUint64(r.buf[:], mix64(r.state))
+			r.left = len(r.buf)
+		}
+		p[i] = r.buf[len(r.buf)-r.left]
+		r.left--
+	}
+	return len(p), nil
+}
+
+// RandomNumeral returns a uniformly random numeral between min and max,
+// inclusive, in the system of min. Randomness is read from crypto/rand unless
+// WithEntropy or WithSeed is given, and draws that would bias the result are
+// rejected rather than reduced with a modulo.
+func RandomNumeral(min, max Numeral, opts ...GeneratorOption) (*Numeral, error) {
+	if !sameValues(min.digitValues, max.digitValues) {
+		return nil, fmt.Errorf("numeral: min and max use different digit values")
+	}
+	c, err := newGeneratorConfig(min.digitValues, opts)
+	if err != nil {
+		return nil, err
+	}
+	low, high := min.bigInt(), max.bigInt()
+	if low.Cmp(high) > 0 {
+		return nil, fmt.Errorf("numeral: min %s is greater than max %s", min.String(), max.String())
+	}
+	size := new(big.Int).Sub(high, low)
+	size.Add(size, big.NewInt(1))
+	x, err := rand.Int(c.entropy, size)
+	if err != nil {
+		return nil, err
+	}
+	return newFromBig(min.digitValues, x.Add(x, low))
+}
+
+// RandomDigits returns a uniformly random numeral of exactly n digits in the
+// system defined by values. Every digit string of length n is equally likely,
+// so the result may start with zeros, as fixed length codes do. Randomness is
+// read the same way as in RandomNumeral.
+func RandomDigits(values []rune, n int, opts ...GeneratorOption) (*Numeral, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("numeral: invalid amount of digits: %d", n)
+	}
+	c, err := newGeneratorConfig(values, opts)
+	if err != nil {
+		return nil, err
+	}
+	size := new(big.Int).Exp(big.NewInt(int64(len(c.values))), big.NewInt(int64(n)), nil)
+	x, err := rand.Int(c.entropy, size)
+	if err != nil {
+		return nil, err
+	}
+	return newFromIndexes(c.values, padIndexes(bigIndexes(len(c.values), x), n)), nil
+}
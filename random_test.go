@@ -0,0 +1,115 @@
+package numeral_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestRandomNumeral(t *testing.T) {
+	randomTests := []struct {
+		name     string
+		min, max string
+	}{
+		{"single value", "7", "7"},
+		{"small range", "10", "1z"},
+		{"beyond int64", "10000000000000000000000000", "zzzzzzzzzzzzzzzzzzzzzzzzzzzzz"},
+	}
+	for _, tt := range randomTests {
+		t.Run(tt.name, func(t *testing.T) {
+			min, _ := numeral.NewNumeral(testValues, tt.min)
+			max, _ := numeral.NewNumeral(testValues, tt.max)
+			for i := 0; i < 100; i++ {
+				got, err := numeral.RandomNumeral(*min, *max)
+				if err != nil {
+					t.Fatalf("expected nil got err: %v", err)
+				}
+				if !between(got.String(), tt.min, tt.max) {
+					t.Fatalf("got: %s out of range [%s, %s]", got.String(), tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+// between compares numerals without leading zeros of the same system whose
+// digit values are in ascending order.
+func between(s, min, max string) bool {
+	less := func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	}
+	return !less(s, min) && !less(max, s)
+}
+
+func TestRandomNumeralIsUniform(t *testing.T) {
+	min, _ := numeral.NewNumeral(decimalValues, "0")
+	max, _ := numeral.NewNumeral(decimalValues, "5")
+	counts := make(map[string]int)
+	for i := 0; i < 6000; i++ {
+		got, err := numeral.RandomNumeral(*min, *max, numeral.WithSeed(uint64(i)))
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		counts[got.String()]++
+	}
+	if len(counts) != 6 {
+		t.Fatalf("got: %d distinct values want: 6", len(counts))
+	}
+	for v, count := range counts {
+		if count < 850 || count > 1150 {
+			t.Errorf("got: %d draws of %s want: about 1000", count, v)
+		}
+	}
+}
+
+func TestRandomSeeded(t *testing.T) {
+	first, err := numeral.RandomDigits(testValues, 12, numeral.WithSeed(42))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	second, _ := numeral.RandomDigits(testValues, 12, numeral.WithSeed(42))
+	if first.String() != second.String() {
+		t.Errorf("got: %s and %s want equal values for the same seed", first.String(), second.String())
+	}
+	other, _ := numeral.RandomDigits(testValues, 12, numeral.WithSeed(43))
+	if first.String() == other.String() {
+		t.Errorf("got: %s for different seeds", other.String())
+	}
+}
+
+func TestRandomDigitsKeepsLeadingZeros(t *testing.T) {
+	got, err := numeral.RandomDigits(decimalValues, 8, numeral.WithEntropy(bytes.NewReader(make([]byte, 64))))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	if got.String() != "00000000" {
+		t.Errorf("got: %s want: 00000000", got.String())
+	}
+	got, _ = numeral.RandomDigits(testValues, 30)
+	if len(got.String()) != 30 {
+		t.Errorf("got: %d digits want: 30", len(got.String()))
+	}
+}
+
+func TestRandomInvalid(t *testing.T) {
+	min, _ := numeral.NewNumeral(decimalValues, "9")
+	max, _ := numeral.NewNumeral(decimalValues, "1")
+	if _, err := numeral.RandomNumeral(*min, *max); err == nil {
+		t.Error("expected error to be thrown on min greater than max")
+	}
+	other, _ := numeral.NewNumeral(hexValues, "f")
+	if _, err := numeral.RandomNumeral(*max, *other); err == nil {
+		t.Error("expected error to be thrown on different digit values")
+	}
+	if _, err := numeral.RandomDigits(decimalValues, 0); err == nil {
+		t.Error("expected error to be thrown on zero digits")
+	}
+	if _, err := numeral.RandomDigits(decimalValues, 4, numeral.WithEntropy(strings.NewReader(""))); err == nil {
+		t.Error("expected error to be thrown on exhausted entropy")
+	}
+}
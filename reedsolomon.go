@@ -0,0 +1,222 @@
+package numeral
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUncorrectable is returned when a numeral has more errors than its parity
+// digits can correct.
+var ErrUncorrectable = errors.New("numeral: too many errors to correct")
+
+// ReedSolomon appends Reed–Solomon parity digits to numerals and corrects
+// them. The digits of a numeral are the elements of a finite field, so the
+// amount of digit values must be a prime power such as 32, and a numeral
+// along with its parity can have up to that amount minus one digits.
+//
+// With n parity digits up to n/2 wrong digits, or up to n wrong digits whose
+// positions are known (erasures), can be corrected.
+type ReedSolomon struct {
+	values    []rune
+	field     *galoisField
+	parity    int
+	generator []int
+}
+
+// NewReedSolomon creates a Reed–Solomon code with parity digits for the system
+// defined by values.
+func NewReedSolomon(values []rune, parity int) (*ReedSolomon, error) {
+	field, err := newGaloisField(len(values))
+	if err != nil {
+		return nil, err
+	}
+	if parity < 1 || parity >= len(values)-1 {
+		return nil, fmt.Errorf("numeral: invalid amount of parity digits: %d", parity)
+	}
+	// the generator has the roots α^1 ... α^parity.
+	generator := []int{1}
+	for i := 1; i <= parity; i++ {
+		generator = field.polyMul(generator, []int{field.neg(field.alphaPow(i)), 1})
+	}
+	return &ReedSolomon{
+		values:    values,
+		field:     field,
+		parity:    parity,
+		generator: generator,
+	}, nil
+}
+
+// AppendParity appends the Reed–Solomon parity digits of rs to the Numeral.
+func (n *Numeral) AppendParity(rs *ReedSolomon) error {
+	if !sameValues(n.digitValues, rs.values) {
+		return fmt.Errorf("numeral: numeral and Reed–Solomon code use different digit values")
+	}
+	indexes := n.digitIndexes()
+	if len(indexes)+rs.parity > len(rs.values)-1 {
+		return fmt.Errorf("numeral: numeral too long for %d parity digits, at most %d digits fit", rs.parity, len(rs.values)-1-rs.parity)
+	}
+	// the remainder of message * x^parity divided by the generator, negated.
+	f := rs.field
+	remainder := make([]int, len(indexes)+rs.parity)
+	for i, d := range indexes {
+		remainder[i] = d
+	}
+	for i := 0; i < len(indexes); i++ {
+		coefficient := remainder[i]
+		if coefficient == 0 {
+			continue
+		}
+		for j := 1; j <= rs.parity; j++ {
+			g := rs.generator[rs.parity-j]
+			remainder[i+j] = f.sub(remainder[i+j], f.mul(coefficient, g))
+		}
+	}
+	for _, r := range remainder[len(indexes):] {
+		d, _ := newDigit(n.digitValues, n.digitValues[f.neg(r)])
+		n.digits.PushBack(d)
+	}
+	return nil
+}
+
+// Correct corrects the wrong digits of a numeral that ends with the parity
+// digits of rs. Erasures are the positions, counting from the left, of digits
+// that are known to be wrong, e.g. unreadable ones. It returns the corrected
+// numeral, parity included, along with the positions of the digits it fixed.
+// ErrUncorrectable is returned when there are too many errors.
+func (rs *ReedSolomon) Correct(n Numeral, erasures []int) (*Numeral, []int, error) {
+	if !sameValues(n.digitValues, rs.values) {
+		return nil, nil, fmt.Errorf("numeral: numeral and Reed–Solomon code use different digit values")
+	}
+	f := rs.field
+	indexes := n.digitIndexes()
+	length := len(indexes)
+	if length <= rs.parity || length > len(rs.values)-1 {
+		return nil, nil, fmt.Errorf("numeral: invalid numeral length %d for %d parity digits", length, rs.parity)
+	}
+	if len(erasures) > rs.parity {
+		return nil, nil, ErrUncorrectable
+	}
+	// the digit at position i is the coefficient of x^(length-1-i).
+	received := make([]int, length)
+	for i, d := range indexes {
+		received[length-1-i] = d
+	}
+	syndromes := make([]int, rs.parity)
+	clean := true
+	for k := range syndromes {
+		syndromes[k] = f.polyEval(received, f.alphaPow(k+1))
+		if syndromes[k] != 0 {
+			clean = false
+		}
+	}
+	if clean {
+		return n.clone(), nil, nil
+	}
+	// erasure locator Γ(x) = Π (1 - X_j x).
+	erasureLocator := []int{1}
+	for _, pos := range erasures {
+		if pos < 0 || pos >= length {
+			return nil, nil, fmt.Errorf("numeral: erasure position %d out of range [0, %d)", pos, length)
+		}
+		x := f.alphaPow(length - 1 - pos)
+		erasureLocator = f.polyMul(erasureLocator, []int{1, f.neg(x)})
+	}
+	// the Forney syndromes only depend on the errors of unknown positions.
+	modified := f.polyMul(erasureLocator, syndromes)[len(erasures):rs.parity]
+	errorLocator := rs.berlekampMassey(modified)
+	if 2*(len(errorLocator)-1)+len(erasures) > rs.parity {
+		return nil, nil, ErrUncorrectable
+	}
+	locator := f.polyMul(errorLocator, erasureLocator)
+	evaluator := f.polyMul(syndromes, locator)
+	if len(evaluator) > rs.parity {
+		evaluator = evaluator[:rs.parity]
+	}
+	// the formal derivative of the locator.
+	derivative := make([]int, len(locator)-1)
+	for j := 1; j < len(locator); j++ {
+		derivative[j-1] = f.mulInt(locator[j], j)
+	}
+	var fixed []int
+	roots := 0
+	for power := 0; power < length; power++ {
+		xInv := f.alphaPow(-power)
+		if f.polyEval(locator, xInv) != 0 {
+			continue
+		}
+		roots++
+		d := f.polyEval(derivative, xInv)
+		if d == 0 {
+			return nil, nil, ErrUncorrectable
+		}
+		magnitude := f.neg(f.div(f.polyEval(evaluator, xInv), d))
+		received[power] = f.sub(received[power], magnitude)
+		if magnitude != 0 {
+			fixed = append(fixed, length-1-power)
+		}
+	}
+	if roots != len(locator)-1 {
+		return nil, nil, ErrUncorrectable
+	}
+	// make sure that the result is a codeword.
+	for k := 0; k < rs.parity; k++ {
+		if f.polyEval(received, f.alphaPow(k+1)) != 0 {
+			return nil, nil, ErrUncorrectable
+		}
+	}
+	sort.Ints(fixed)
+	corrected := make([]int, length)
+	for i := range corrected {
+		corrected[i] = received[length-1-i]
+	}
+	return newFromIndexes(n.digitValues, corrected), fixed, nil
+}
+
+// berlekampMassey returns the shortest connection polynomial of syndromes,
+// which is the locator of the errors.
+func (rs *ReedSolomon) berlekampMassey(syndromes []int) []int {
+	f := rs.field
+	c := []int{1}
+	b := []int{1}
+	l, m, last := 0, 1, 1
+	for k := range syndromes {
+		discrepancy := syndromes[k]
+		for i := 1; i <= l && i < len(c); i++ {
+			discrepancy = f.add(discrepancy, f.mul(c[i], syndromes[k-i]))
+		}
+		if discrepancy == 0 {
+			m++
+			continue
+		}
+		// c(x) - discrepancy/last * x^m * b(x)
+		scale := f.div(discrepancy, last)
+		next := make([]int, maxInt(len(c), len(b)+m))
+		copy(next, c)
+		for i, v := range b {
+			next[i+m] = f.sub(next[i+m], f.mul(scale, v))
+		}
+		if 2*l <= k {
+			l = k + 1 - l
+			b = c
+			last = discrepancy
+			m = 1
+		} else {
+			m++
+		}
+		c = next
+	}
+	// drop the trailing zero coefficients.
+	for len(c) > 1 && c[len(c)-1] == 0 {
+		c = c[:len(c)-1]
+	}
+	return c
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,101 @@
+package numeral_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestReedSolomonCorrectsErrors(t *testing.T) {
+	systems := []struct {
+		name   string
+		values []rune
+		data   string
+		parity int
+	}{
+		{"crockford", numeral.CrockfordValues, "7ZQ3MX0KD2", 6},
+		{"base31", []rune("0123456789ABCDEFGHIJKLMNOPQRSTU"), "HELLO123", 8},
+		{"base9", []rune("012345678"), "1234", 4},
+		{"base25", []rune("ABCDEFGHIJKLMNOPQRSTUVWXY"), "CORRECT", 6},
+	}
+	r := rand.New(rand.NewSource(3))
+	for _, tt := range systems {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := numeral.NewReedSolomon(tt.values, tt.parity)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			code, _ := numeral.NewNumeral(tt.values, tt.data)
+			if err := code.AppendParity(rs); err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			want := code.String()
+			if got, want := len([]rune(want)), len(tt.data)+tt.parity; got != want {
+				t.Fatalf("got: %d digits want: %d", got, want)
+			}
+			for round := 0; round < 50; round++ {
+				errorCount := r.Intn(tt.parity/2 + 1)
+				erasureCount := r.Intn(tt.parity - 2*errorCount + 1)
+				positions := r.Perm(len(want))[:errorCount+erasureCount]
+				s := []rune(want)
+				for _, p := range positions {
+					s[p] = tt.values[(indexOfRune(s[p], tt.values)+1+r.Intn(len(tt.values)-1))%len(tt.values)]
+				}
+				received, _ := numeral.NewNumeral(tt.values, string(s))
+				corrected, fixed, err := rs.Correct(*received, positions[errorCount:])
+				if err != nil {
+					t.Fatalf("%d errors %d erasures: expected nil got err: %v", errorCount, erasureCount, err)
+				}
+				if corrected.String() != want {
+					t.Fatalf("got: %s want: %s", corrected.String(), want)
+				}
+				sort.Ints(positions)
+				if len(positions) == 0 {
+					positions = nil
+				}
+				if !reflect.DeepEqual(fixed, positions) {
+					t.Fatalf("fixed got: %v want: %v", fixed, positions)
+				}
+			}
+		})
+	}
+}
+
+func TestReedSolomonTooManyErrors(t *testing.T) {
+	rs, _ := numeral.NewReedSolomon(numeral.CrockfordValues, 4)
+	code, _ := numeral.NewNumeral(numeral.CrockfordValues, "ABCDEFGH")
+	code.AppendParity(rs)
+	s := []rune(code.String())
+	s[0], s[3], s[6] = '0', '0', '0'
+	received, _ := numeral.NewNumeral(numeral.CrockfordValues, string(s))
+	corrected, _, err := rs.Correct(*received, nil)
+	if err == nil && corrected.String() == code.String() {
+		t.Errorf("expected 3 errors with 4 parity digits not to be corrected")
+	}
+}
+
+func TestNewReedSolomonNotPrimePowerThrowsErr(t *testing.T) {
+	if _, err := numeral.NewReedSolomon(testValues, 4); err == nil {
+		t.Errorf("expected error to be thrown on NewReedSolomon")
+	}
+}
+
+func TestAppendParityTooLongThrowsErr(t *testing.T) {
+	rs, _ := numeral.NewReedSolomon([]rune("01234567"), 2)
+	code, _ := numeral.NewNumeral([]rune("01234567"), "123456")
+	if err := code.AppendParity(rs); err == nil {
+		t.Errorf("expected error to be thrown on AppendParity")
+	}
+}
+
+func indexOfRune(r rune, values []rune) int {
+	for i, v := range values {
+		if v == r {
+			return i
+		}
+	}
+	return -1
+}
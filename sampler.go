@@ -0,0 +1,92 @@
+package numeral
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrExhausted is returned when every numeral of a range has been issued.
+var ErrExhausted = errors.New("numeral: range exhausted")
+
+// SamplerState is everything a Sampler needs, besides its range, to carry on
+// where it stopped. It can be stored, e.g. as JSON, and handed to
+// ResumeSampler after a restart.
+type SamplerState struct {
+	// Seed keys the permutation of the range.
+	Seed uint64
+	// Issued is the amount of numerals issued so far.
+	Issued uint64
+}
+
+// Sampler issues distinct random numerals of a range, without replacement,
+// until the range is exhausted. Instead of remembering the issued numerals it
+// walks a keyed permutation of the range, so its whole state is a seed and a
+// counter. The permutation is not a cipher: it spreads the numerals well, but
+// must not be relied on to keep them unguessable. It is safe for concurrent
+// use.
+type Sampler struct {
+	mu      sync.Mutex
+	shuffle *ShuffleIterator
+	state   SamplerState
+}
+
+// NewSampler creates a sampler over all the numerals from start to end, both
+// inclusive, in the system of start and with at least as many digits as start
+// has. The seed is read from crypto/rand unless WithEntropy or WithSeed is
+// given.
+func NewSampler(start, end Numeral, opts ...GeneratorOption) (*Sampler, error) {
+	c, err := newGeneratorConfig(start.digitValues, opts)
+	if err != nil {
+		return nil, err
+	}
+	var seed [8]byte
+	if _, err := io.ReadFull(c.entropy, seed[:]); err != nil {
+		return nil, err
+	}
+	return ResumeSampler(start, end, SamplerState{Seed: binary.BigEndian.Uint64(seed[:])})
+}
+
+// ResumeSampler recreates a sampler over the same range from its saved state.
+// Numerals issued before the state was saved are not issued again.
+func ResumeSampler(start, end Numeral, state SamplerState) (*Sampler, error) {
+	shuffle, err := NewShuffleIterator(start, end, state.Seed)
+	if err != nil {
+		return nil, err
+	}
+	if state.Issued > shuffle.Len() {
+		return nil, fmt.Errorf("numeral: sampler state issued %d numerals, but the range only has %d", state.Issued, shuffle.Len())
+	}
+	return &Sampler{shuffle: shuffle, state: state}, nil
+}
+
+// Next returns a numeral that has not been issued yet, or ErrExhausted.
+func (s *Sampler) Next() (*Numeral, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state.Issued >= s.shuffle.Len() {
+		return nil, ErrExhausted
+	}
+	n, err := s.shuffle.At(s.state.Issued)
+	if err != nil {
+		return nil, err
+	}
+	s.state.Issued++
+	return n, nil
+}
+
+// Remaining returns the amount of numerals that have not been issued yet.
+func (s *Sampler) Remaining() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shuffle.Len() - s.state.Issued
+}
+
+// State returns the current state of the sampler.
+func (s *Sampler) State() SamplerState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
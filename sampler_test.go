@@ -0,0 +1,86 @@
+package numeral_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestSamplerIssuesEveryNumeralOnce(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "000")
+	end, _ := numeral.NewNumeral(testValues, "0zz")
+	s, err := numeral.NewSampler(*start, *end)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	seen := make(map[string]bool)
+	for {
+		n, err := s.Next()
+		if err == numeral.ErrExhausted {
+			break
+		}
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if seen[n.String()] {
+			t.Fatalf("got: %s twice", n.String())
+		}
+		seen[n.String()] = true
+	}
+	if len(seen) != 36*36 {
+		t.Errorf("got: %d numerals want: %d", len(seen), 36*36)
+	}
+	if s.Remaining() != 0 {
+		t.Errorf("got: %d remaining want: 0", s.Remaining())
+	}
+}
+
+func TestSamplerResume(t *testing.T) {
+	start, _ := numeral.NewNumeral(decimalValues, "1000")
+	end, _ := numeral.NewNumeral(decimalValues, "9999")
+	s, _ := numeral.NewSampler(*start, *end, numeral.WithSeed(7))
+	var issued []string
+	for i := 0; i < 100; i++ {
+		n, _ := s.Next()
+		issued = append(issued, n.String())
+	}
+	saved, err := json.Marshal(s.State())
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	want := make([]string, 50)
+	for i := range want {
+		n, _ := s.Next()
+		want[i] = n.String()
+	}
+
+	var state numeral.SamplerState
+	if err := json.Unmarshal(saved, &state); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	resumed, err := numeral.ResumeSampler(*start, *end, state)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	for i := range want {
+		n, _ := resumed.Next()
+		if n.String() != want[i] {
+			t.Errorf("got: %s want: %s", n.String(), want[i])
+		}
+	}
+	if resumed.Remaining() != 9000-150 {
+		t.Errorf("got: %d remaining want: %d", resumed.Remaining(), 9000-150)
+	}
+}
+
+func TestResumeSamplerInvalid(t *testing.T) {
+	start, _ := numeral.NewNumeral(decimalValues, "10")
+	end, _ := numeral.NewNumeral(decimalValues, "19")
+	if _, err := numeral.ResumeSampler(*start, *end, numeral.SamplerState{Issued: 11}); err == nil {
+		t.Error("expected error to be thrown on a state issuing more than the range")
+	}
+	if _, err := numeral.NewSampler(*end, *start); err == nil {
+		t.Error("expected error to be thrown on an invalid range")
+	}
+}
@@ -0,0 +1,151 @@
+package numeral
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// feistelRounds is the amount of rounds of the Feistel network that shuffles
+// the positions of a range.
+const feistelRounds = 8
+
+// feistel is a keyed permutation of [0, size) built out of a balanced Feistel
+// network over the smallest even amount of bits that fits size. Values that
+// fall outside of the range are walked through the network again until they
+// land inside it (cycle walking).
+type feistel struct {
+	size      uint64
+	halfBits  uint
+	halfMask  uint64
+	roundKeys [feistelRounds]uint64
+}
+
+// newFeistel creates a permutation of [0, size) keyed by seed.
+func newFeistel(size uint64, seed uint64) feistel {
+	w := uint(bits.Len64(size - 1))
+	if w < 2 {
+		w = 2
+	}
+	w += w % 2
+	f := feistel{
+		size:     size,
+		halfBits: w / 2,
+		halfMask: 1<<(w/2) - 1,
+	}
+	state := seed
+	for i := range f.roundKeys {
+		state += 0x9e3779b97f4a7c15
+		f.roundKeys[i] = mix64(state)
+	}
+	return f
+}
+
+// permute returns the position that x is moved to.
+func (f feistel) permute(x uint64) uint64 {
+	for {
+		x = f.encrypt(x)
+		if x < f.size {
+			return x
+		}
+	}
+}
+
+// encrypt runs x through all the rounds of the network.
+func (f feistel) encrypt(x uint64) uint64 {
+	left, right := x>>f.halfBits, x&f.halfMask
+	for _, k := range f.roundKeys {
+		left, right = right, left^(mix64(right^k)&f.halfMask)
+	}
+	return left<<f.halfBits | right
+}
+
+// mix64 is the splitmix64 finalizer, used as the round function.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// ShuffleIterator visits every numeral of a range exactly once, in a
+// pseudo-random order that is fully determined by a seed. Unlike Increment it
+// does not hit the same prefixes in bursts, which spreads the load evenly on
+// anything that is sharded by prefix.
+type ShuffleIterator struct {
+	values  []rune
+	start   *big.Int
+	width   int
+	perm    feistel
+	i       uint64
+	current *Numeral
+}
+
+// NewShuffleIterator creates a shuffled iterator over all the numerals from
+// start to end, both inclusive. The numerals are produced in the system of
+// start and with at least as many digits as start has.
+func NewShuffleIterator(start, end Numeral, seed uint64) (*ShuffleIterator, error) {
+	from, to := start.bigInt(), end.bigInt()
+	if from.Cmp(to) > 0 {
+		return nil, fmt.Errorf("numeral: invalid range, %s is greater than %s", start.String(), end.String())
+	}
+	size := new(big.Int).Sub(to, from)
+	size.Add(size, big.NewInt(1))
+	if !size.IsUint64() {
+		return nil, fmt.Errorf("numeral: range from %s to %s is too large to shuffle", start.String(), end.String())
+	}
+	return &ShuffleIterator{
+		values: start.digitValues,
+		start:  from,
+		width:  start.digits.Len(),
+		perm:   newFeistel(size.Uint64(), seed),
+	}, nil
+}
+
+// Len returns the amount of numerals in the range.
+func (s *ShuffleIterator) Len() uint64 {
+	return s.perm.size
+}
+
+// At returns the i-th numeral of the shuffled order, without having to visit
+// the ones before it.
+func (s *ShuffleIterator) At(i uint64) (*Numeral, error) {
+	if i >= s.perm.size {
+		return nil, fmt.Errorf("numeral: position %d out of range [0, %d)", i, s.perm.size)
+	}
+	x := new(big.Int).SetUint64(s.perm.permute(i))
+	x.Add(x, s.start)
+	return newFromIndexes(s.values, padIndexes(bigIndexes(len(s.values), x), s.width)), nil
+}
+
+// Next advances the iterator to the next numeral of the shuffled order. It
+// returns false once every numeral of the range has been visited.
+func (s *ShuffleIterator) Next() bool {
+	if s.i >= s.perm.size {
+		return false
+	}
+	s.current, _ = s.At(s.i)
+	s.i++
+	return true
+}
+
+// Numeral returns the numeral the iterator is currently at, or nil before the
+// first call to Next.
+func (s *ShuffleIterator) Numeral() *Numeral {
+	if s.current == nil {
+		return nil
+	}
+	return s.current.clone()
+}
+
+// padIndexes prepends zero digits until there are at least width digits.
+func padIndexes(indexes []int, width int) []int {
+	if len(indexes) >= width {
+		return indexes
+	}
+	padded := make([]int, width)
+	copy(padded[width-len(indexes):], indexes)
+	return padded
+}
@@ -0,0 +1,99 @@
+package numeral_test
+
+import (
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestShuffleIteratorVisitsEveryNumeralOnce(t *testing.T) {
+	rangeTests := []struct {
+		start string
+		end   string
+		want  int
+	}{
+		{"0", "0", 1},
+		{"00", "zz", 1296},
+		{"a", "1a", 37},
+		{"100", "2ab", 1668},
+	}
+	for _, tt := range rangeTests {
+		t.Run(tt.start+"-"+tt.end, func(t *testing.T) {
+			start, _ := numeral.NewNumeral(testValues, tt.start)
+			end, _ := numeral.NewNumeral(testValues, tt.end)
+			it, err := numeral.NewShuffleIterator(*start, *end, 42)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got := it.Len(); got != uint64(tt.want) {
+				t.Fatalf("Len got: %d want: %d", got, tt.want)
+			}
+			seen := make(map[string]bool)
+			sequential := true
+			previous := -1
+			for it.Next() {
+				n := it.Numeral()
+				if n.Decimal() < start.Decimal() || n.Decimal() > end.Decimal() {
+					t.Fatalf("numeral %s out of range", n.String())
+				}
+				if seen[n.String()] {
+					t.Fatalf("numeral %s visited twice", n.String())
+				}
+				seen[n.String()] = true
+				if previous != -1 && n.Decimal() != previous+1 {
+					sequential = false
+				}
+				previous = n.Decimal()
+			}
+			if len(seen) != tt.want {
+				t.Errorf("got: %d numerals want: %d", len(seen), tt.want)
+			}
+			if tt.want > 100 && sequential {
+				t.Errorf("expected a shuffled order")
+			}
+		})
+	}
+}
+
+func TestShuffleIteratorIsDeterministic(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "000")
+	end, _ := numeral.NewNumeral(testValues, "zzz")
+	it, _ := numeral.NewShuffleIterator(*start, *end, 7)
+	it2, _ := numeral.NewShuffleIterator(*start, *end, 7)
+	if it.Numeral() != nil {
+		t.Errorf("got: %v want nil before Next", it.Numeral())
+	}
+	other, _ := numeral.NewShuffleIterator(*start, *end, 8)
+	differs := false
+	for i := uint64(0); it.Next(); i++ {
+		it2.Next()
+		other.Next()
+		if it.Numeral().String() != it2.Numeral().String() {
+			t.Fatalf("same seed produced different orders at %d", i)
+		}
+		if it.Numeral().String() != other.Numeral().String() {
+			differs = true
+		}
+		at, err := it.At(i)
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if at.String() != it.Numeral().String() {
+			t.Fatalf("At(%d) got: %s want: %s", i, at.String(), it.Numeral().String())
+		}
+		if i > 500 {
+			break
+		}
+	}
+	if !differs {
+		t.Errorf("expected different seeds to produce different orders")
+	}
+}
+
+func TestShuffleIteratorInvalidRange(t *testing.T) {
+	start, _ := numeral.NewNumeral(testValues, "10")
+	end, _ := numeral.NewNumeral(testValues, "z")
+	if _, err := numeral.NewShuffleIterator(*start, *end, 1); err == nil {
+		t.Errorf("expected error to be thrown on NewShuffleIterator")
+	}
+}
@@ -0,0 +1,222 @@
+package numeral
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SqidsValues are the default digit values of Sqids.
+var SqidsValues = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+// sqidsMaxMinLength is the largest minimum length Sqids accepts.
+const sqidsMaxMinLength = 255
+
+// Sqids turns lists of non negative integers into short IDs that do not look
+// sequential, and back, with the Sqids algorithm. No blocklist is built in:
+// only the words given to NewSqidsWithBlocklist are blocked.
+type Sqids struct {
+	values    []rune
+	minLength int
+	blocklist []string
+}
+
+// NewSqidsWithBlocklist creates a Sqids encoder for the digit values, which
+// must be unique and at least 3. IDs are padded to minLength, and never
+// contain any word of blocklist, ignoring case. A nil blocklist blocks
+// nothing.
+func NewSqidsWithBlocklist(values []rune, minLength int, blocklist []string) (*Sqids, error) {
+	if len(values) < 3 {
+		return nil, fmt.Errorf("numeral: at least 3 digit values are needed for Sqids, got: %d", len(values))
+	}
+	seen := make(map[rune]bool, len(values))
+	for _, v := range values {
+		if seen[v] {
+			return nil, fmt.Errorf("numeral: duplicate digit value %q", v)
+		}
+		seen[v] = true
+	}
+	if minLength < 0 || minLength > sqidsMaxMinLength {
+		return nil, fmt.Errorf("numeral: invalid Sqids minimum length %d, must be between 0 and %d", minLength, sqidsMaxMinLength)
+	}
+	// only words of at least 3 characters that can be spelled with the digit
+	// values can ever match.
+	lower := make(map[rune]bool, len(values))
+	for _, v := range values {
+		lower[unicode.ToLower(v)] = true
+	}
+	var words []string
+	for _, word := range blocklist {
+		word = strings.ToLower(word)
+		if len([]rune(word)) < 3 {
+			continue
+		}
+		spelled := true
+		for _, r := range word {
+			if !lower[r] {
+				spelled = false
+				break
+			}
+		}
+		if spelled {
+			words = append(words, word)
+		}
+	}
+	return &Sqids{
+		values:    sqidsShuffle(append([]rune(nil), values...)),
+		minLength: minLength,
+		blocklist: words,
+	}, nil
+}
+
+// Encode returns the ID of numbers. The ID of no numbers is empty.
+func (s *Sqids) Encode(numbers []uint64) (string, error) {
+	if len(numbers) == 0 {
+		return "", nil
+	}
+	return s.encode(numbers, 0)
+}
+
+// encode renders numbers, rotating the digit values by increment more places
+// than the numbers alone call for, and retrying with the next increment when
+// the ID contains a blocked word.
+func (s *Sqids) encode(numbers []uint64, increment int) (string, error) {
+	base := len(s.values)
+	if increment > base {
+		return "", fmt.Errorf("numeral: no Sqids ID without blocked words found for %v", numbers)
+	}
+	offset := len(numbers)
+	for i, v := range numbers {
+		offset += int(s.values[v%uint64(base)]) + i
+	}
+	offset = (offset%base + increment) % base
+	values := append(append([]rune(nil), s.values[offset:]...), s.values[:offset]...)
+	prefix := values[0]
+	sqidsReverse(values)
+
+	id := []rune{prefix}
+	for i, v := range numbers {
+		id = append(id, sqidsDigits(v, values[1:])...)
+		if i < len(numbers)-1 {
+			id = append(id, values[0])
+			values = sqidsShuffle(values)
+		}
+	}
+	if len(id) < s.minLength {
+		id = append(id, values[0])
+		for len(id) < s.minLength {
+			values = sqidsShuffle(values)
+			n := s.minLength - len(id)
+			if n > base {
+				n = base
+			}
+			id = append(id, values[:n]...)
+		}
+	}
+	if s.blocked(string(id)) {
+		return s.encode(numbers, increment+1)
+	}
+	return string(id), nil
+}
+
+// Decode returns the numbers of an ID. IDs with characters that are not digit
+// values decode to no numbers.
+func (s *Sqids) Decode(id string) []uint64 {
+	digits := []rune(id)
+	if len(digits) == 0 {
+		return nil
+	}
+	for _, r := range digits {
+		if indexOf(r, s.values) == -1 {
+			return nil
+		}
+	}
+	offset := indexOf(digits[0], s.values)
+	values := append(append([]rune(nil), s.values[offset:]...), s.values[:offset]...)
+	sqidsReverse(values)
+
+	var numbers []uint64
+	rest := digits[1:]
+	for len(rest) > 0 {
+		separator := values[0]
+		chunk := rest
+		rest = nil
+		for i, r := range chunk {
+			if r == separator {
+				chunk, rest = chunk[:i], chunk[i+1:]
+				break
+			}
+		}
+		if len(chunk) == 0 {
+			// padding follows.
+			return numbers
+		}
+		numbers = append(numbers, sqidsNumber(chunk, values[1:]))
+		if len(rest) > 0 {
+			values = sqidsShuffle(values)
+		}
+	}
+	return numbers
+}
+
+// blocked reports whether id contains a word of the blocklist. Short IDs and
+// words must match exactly, and words with digits only at either end of id.
+func (s *Sqids) blocked(id string) bool {
+	id = strings.ToLower(id)
+	length := len([]rune(id))
+	for _, word := range s.blocklist {
+		wordLength := len([]rune(word))
+		switch {
+		case wordLength > length:
+		case length <= 3 || wordLength <= 3:
+			if id == word {
+				return true
+			}
+		case strings.IndexFunc(word, unicode.IsDigit) != -1:
+			if strings.HasPrefix(id, word) || strings.HasSuffix(id, word) {
+				return true
+			}
+		case strings.Contains(id, word):
+			return true
+		}
+	}
+	return false
+}
+
+// sqidsShuffle shuffles values in place, the same way for the same values.
+func sqidsShuffle(values []rune) []rune {
+	for i, j := 0, len(values)-1; j > 0; i, j = i+1, j-1 {
+		r := (i*j + int(values[i]) + int(values[j])) % len(values)
+		values[i], values[r] = values[r], values[i]
+	}
+	return values
+}
+
+// sqidsReverse reverses values in place.
+func sqidsReverse(values []rune) {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+}
+
+// sqidsDigits renders v with values, most significant digit first.
+func sqidsDigits(v uint64, values []rune) []rune {
+	base := uint64(len(values))
+	var digits []rune
+	for {
+		digits = append([]rune{values[v%base]}, digits...)
+		v /= base
+		if v == 0 {
+			return digits
+		}
+	}
+}
+
+// sqidsNumber is the inverse of sqidsDigits.
+func sqidsNumber(digits []rune, values []rune) uint64 {
+	var v uint64
+	for _, r := range digits {
+		v = v*uint64(len(values)) + uint64(indexOf(r, values))
+	}
+	return v
+}
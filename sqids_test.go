@@ -0,0 +1,79 @@
+package numeral_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestSqids(t *testing.T) {
+	sqidsTests := []struct {
+		name      string
+		values    []rune
+		minLength int
+		blocklist []string
+		numbers   []uint64
+		want      string
+	}{
+		{"default", numeral.SqidsValues, 0, nil, []uint64{1, 2, 3}, "86Rf07"},
+		{"hex values", []rune("0123456789abcdef"), 0, nil, []uint64{1, 2, 3}, "489158"},
+		{"empty blocklist", numeral.SqidsValues, 0, nil, []uint64{4572721}, "aho1e"},
+		{"blocked", numeral.SqidsValues, 0, []string{"aho1e"}, []uint64{4572721}, "JExTR"},
+		{"min length", numeral.SqidsValues, 62, nil, []uint64{1, 2, 3}, "86Rf07xd4zBmiJXQG6otHEbew02c3PWsUOLZxADhCpKj7aVFv9I8RquYrNlSTM"},
+	}
+	for _, tt := range sqidsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := numeral.NewSqidsWithBlocklist(tt.values, tt.minLength, tt.blocklist)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			got, err := s.Encode(tt.numbers)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+			if decoded := s.Decode(got); !reflect.DeepEqual(decoded, tt.numbers) {
+				t.Errorf("got: %v want: %v", decoded, tt.numbers)
+			}
+		})
+	}
+}
+
+func TestSqidsRoundTrip(t *testing.T) {
+	s, err := numeral.NewSqidsWithBlocklist([]rune("αβγδεζηθικλμνξοπρστυφχψω"), 10, []string{"αβγ"})
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	lists := [][]uint64{{0}, {0, 0, 0}, {1 << 63, 42}, {18446744073709551615}, {7, 1000000, 3}}
+	for _, numbers := range lists {
+		id, err := s.Encode(numbers)
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if len([]rune(id)) < 10 {
+			t.Errorf("got: %s shorter than the minimum length", id)
+		}
+		if got := s.Decode(id); !reflect.DeepEqual(got, numbers) {
+			t.Errorf("got: %v want: %v", got, numbers)
+		}
+	}
+}
+
+func TestSqidsInvalid(t *testing.T) {
+	if _, err := numeral.NewSqidsWithBlocklist([]rune("ab"), 0, nil); err == nil {
+		t.Error("expected error to be thrown on too few digit values")
+	}
+	if _, err := numeral.NewSqidsWithBlocklist([]rune("abca"), 0, nil); err == nil {
+		t.Error("expected error to be thrown on duplicate digit values")
+	}
+	if _, err := numeral.NewSqidsWithBlocklist(numeral.SqidsValues, 256, nil); err == nil {
+		t.Error("expected error to be thrown on a too large minimum length")
+	}
+	s, _ := numeral.NewSqidsWithBlocklist(numeral.SqidsValues, 0, nil)
+	if got := s.Decode("86Rf0*"); got != nil {
+		t.Errorf("got: %v want: nil", got)
+	}
+}
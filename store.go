@@ -0,0 +1,229 @@
+package numeral
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/slysterous/numeral/internal/atomicfile"
+)
+
+// Block is a contiguous range of values, both ends inclusive.
+type Block struct {
+	Start *big.Int `json:"start"`
+	End   *big.Int `json:"end"`
+}
+
+// size returns the amount of values in the block.
+func (b Block) size() *big.Int {
+	s := new(big.Int).Sub(b.End, b.Start)
+	return s.Add(s, big.NewInt(1))
+}
+
+// Store leases non overlapping blocks of named sequences that start from zero.
+// Implementations must be safe for concurrent use, and the ones shared between
+// processes must be safe for use by several processes at once.
+type Store interface {
+	// Lease reserves a block of at most size values of the named sequence.
+	// Returned tails are leased again first, so the block can be shorter.
+	Lease(name string, size uint64) (Block, error)
+	// Return gives back the unused tail of a leased block. It fails for
+	// values that were never leased and for values that were already
+	// returned and not leased again, so a tail must be returned only once.
+	Return(name string, tail Block) error
+	// Tails lists the returned tails of the named sequence that have not
+	// been leased again.
+	Tails(name string) ([]Block, error)
+}
+
+// storedSequence is the state of a sequence in a store.
+type storedSequence struct {
+	Next  *big.Int `json:"next"`
+	Tails []Block  `json:"tails"`
+}
+
+// lease takes a block of at most size values out of the sequence.
+func (s *storedSequence) lease(size uint64) Block {
+	n := new(big.Int).SetUint64(size)
+	if len(s.Tails) > 0 {
+		tail := s.Tails[0]
+		if tail.size().Cmp(n) <= 0 {
+			s.Tails = s.Tails[1:]
+			return tail
+		}
+		rest := new(big.Int).Add(tail.Start, n)
+		s.Tails[0] = Block{Start: rest, End: tail.End}
+		return Block{Start: tail.Start, End: new(big.Int).Sub(rest, big.NewInt(1))}
+	}
+	block := Block{Start: new(big.Int).Set(s.Next)}
+	s.Next.Add(s.Next, n)
+	block.End = new(big.Int).Sub(s.Next, big.NewInt(1))
+	return block
+}
+
+// giveBack records a returned tail after checking that it was leased and is
+// not already waiting to be leased again.
+func (s *storedSequence) giveBack(tail Block) error {
+	if tail.Start == nil || tail.End == nil || tail.Start.Sign() < 0 || tail.Start.Cmp(tail.End) > 0 {
+		return fmt.Errorf("numeral: invalid returned block [%v, %v]", tail.Start, tail.End)
+	}
+	if tail.End.Cmp(s.Next) >= 0 {
+		return fmt.Errorf("numeral: returned block [%v, %v] was never leased", tail.Start, tail.End)
+	}
+	for _, t := range s.Tails {
+		if tail.Start.Cmp(t.End) <= 0 && t.Start.Cmp(tail.End) <= 0 {
+			return fmt.Errorf("numeral: returned block [%v, %v] overlaps the returned block [%v, %v]", tail.Start, tail.End, t.Start, t.End)
+		}
+	}
+	s.Tails = append(s.Tails, Block{Start: new(big.Int).Set(tail.Start), End: new(big.Int).Set(tail.End)})
+	return nil
+}
+
+// copyBlocks returns a deep copy of blocks.
+func copyBlocks(blocks []Block) []Block {
+	copied := make([]Block, len(blocks))
+	for i, b := range blocks {
+		copied[i] = Block{Start: new(big.Int).Set(b.Start), End: new(big.Int).Set(b.End)}
+	}
+	return copied
+}
+
+// MemoryStore is a Store that keeps its sequences in memory, e.g. for tests.
+type MemoryStore struct {
+	mu        sync.Mutex
+	sequences map[string]*storedSequence
+}
+
+// NewMemoryStore creates an empty in memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sequences: make(map[string]*storedSequence)}
+}
+
+// sequence returns the named sequence, creating it when needed.
+func (m *MemoryStore) sequence(name string) *storedSequence {
+	s, ok := m.sequences[name]
+	if !ok {
+		s = &storedSequence{Next: new(big.Int)}
+		m.sequences[name] = s
+	}
+	return s
+}
+
+// Lease reserves a block of at most size values of the named sequence.
+func (m *MemoryStore) Lease(name string, size uint64) (Block, error) {
+	if size == 0 {
+		return Block{}, fmt.Errorf("numeral: invalid lease size: %d", size)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sequence(name).lease(size), nil
+}
+
+// Return gives back the unused tail of a leased block.
+func (m *MemoryStore) Return(name string, tail Block) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sequence(name).giveBack(tail)
+}
+
+// Tails lists the returned tails of the named sequence.
+func (m *MemoryStore) Tails(name string) ([]Block, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copyBlocks(m.sequence(name).Tails), nil
+}
+
+// FileStore is a Store that keeps its sequences in a local JSON file. Every
+// operation holds an exclusive lock on a companion .lock file, so that
+// several processes on the same machine can share the store, and replaces
+// the file atomically. On platforms without file locks, such as js/wasm,
+// plan9, solaris and aix, the lock only holds within the process.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store backed by the file at path, which is created
+// on the first lease.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// update runs fn on the sequences of the file under the lock, and saves them
+// when fn succeeds and changed them.
+func (f *FileStore) update(fn func(map[string]*storedSequence) (bool, error)) error {
+	lock, err := os.OpenFile(f.path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return err
+	}
+	defer unlockFile(lock)
+
+	sequences := make(map[string]*storedSequence)
+	data, err := ioutil.ReadFile(f.path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &sequences); err != nil {
+			return fmt.Errorf("numeral: invalid store file %s: %v", f.path, err)
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	changed, err := fn(sequences)
+	if err != nil || !changed {
+		return err
+	}
+	data, err = json.Marshal(sequences)
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(f.path, append(data, '\n'))
+}
+
+// fileSequence returns the named sequence of sequences, creating it when
+// needed.
+func fileSequence(sequences map[string]*storedSequence, name string) *storedSequence {
+	s, ok := sequences[name]
+	if !ok || s.Next == nil {
+		s = &storedSequence{Next: new(big.Int)}
+		sequences[name] = s
+	}
+	return s
+}
+
+// Lease reserves a block of at most size values of the named sequence.
+func (f *FileStore) Lease(name string, size uint64) (Block, error) {
+	if size == 0 {
+		return Block{}, fmt.Errorf("numeral: invalid lease size: %d", size)
+	}
+	var block Block
+	err := f.update(func(sequences map[string]*storedSequence) (bool, error) {
+		block = fileSequence(sequences, name).lease(size)
+		return true, nil
+	})
+	return block, err
+}
+
+// Return gives back the unused tail of a leased block.
+func (f *FileStore) Return(name string, tail Block) error {
+	return f.update(func(sequences map[string]*storedSequence) (bool, error) {
+		return true, fileSequence(sequences, name).giveBack(tail)
+	})
+}
+
+// Tails lists the returned tails of the named sequence.
+func (f *FileStore) Tails(name string) ([]Block, error) {
+	var tails []Block
+	err := f.update(func(sequences map[string]*storedSequence) (bool, error) {
+		if s, ok := sequences[name]; ok {
+			tails = copyBlocks(s.Tails)
+		}
+		return false, nil
+	})
+	return tails, err
+}
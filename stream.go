@@ -0,0 +1,247 @@
+package numeral
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// StreamOption configures a stream encoder or decoder.
+type StreamOption func(*streamConfig)
+
+// streamConfig holds the settings shared by stream encoders and decoders.
+type streamConfig struct {
+	padding    rune
+	hasPadding bool
+	lineLength int
+}
+
+// WithPadding pads the encoded output with r up to a whole block of bits, the
+// way base32 and base64 use '='. The decoder must be given the same padding.
+func WithPadding(r rune) StreamOption {
+	return func(c *streamConfig) {
+		c.padding = r
+		c.hasPadding = true
+	}
+}
+
+// WithLineLength wraps the encoded output with a new line every n digits.
+// Decoders always skip new lines.
+func WithLineLength(n int) StreamOption {
+	return func(c *streamConfig) {
+		c.lineLength = n
+	}
+}
+
+// streamSystem holds what a stream needs to know about a power of two system.
+type streamSystem struct {
+	values []rune
+	// bits is the amount of bits that every digit carries.
+	bits uint
+	// blockDigits is the amount of digits that make up a whole number of bytes.
+	blockDigits int
+	config      streamConfig
+}
+
+// newStreamSystem validates values and the options of a stream.
+func newStreamSystem(values []rune, opts []StreamOption) (*streamSystem, error) {
+	base := len(values)
+	if base < 2 || base > 1<<16 || bits.OnesCount(uint(base)) != 1 {
+		return nil, fmt.Errorf("numeral: stream encoding needs a power of two amount of digit values, got: %d", base)
+	}
+	s := streamSystem{
+		values: values,
+		bits:   uint(bits.TrailingZeros(uint(base))),
+	}
+	// the block is the least common multiple of the digit bits and 8.
+	s.blockDigits = 8
+	for s.blockDigits%2 == 0 && (s.blockDigits/2*int(s.bits))%8 == 0 {
+		s.blockDigits /= 2
+	}
+	for _, opt := range opts {
+		opt(&s.config)
+	}
+	if s.config.hasPadding && indexOf(s.config.padding, values) != -1 {
+		return nil, fmt.Errorf("numeral: padding %q is one of the digit values", s.config.padding)
+	}
+	if s.config.lineLength < 0 {
+		return nil, fmt.Errorf("numeral: invalid line length: %d", s.config.lineLength)
+	}
+	return &s, nil
+}
+
+// Encoder encodes the bytes written to it as digits of a power of two system
+// and writes them to an underlying writer. It only keeps the few bits that do
+// not make up a whole digit, so streams of any size are encoded in constant
+// memory. Close must be called to flush the last digit and the padding.
+type Encoder struct {
+	system  *streamSystem
+	w       io.Writer
+	acc     uint32
+	nbits   uint
+	digits  int
+	column  int
+	buf     []byte
+	closed  bool
+	lastErr error
+}
+
+// NewEncoder creates a stream encoder that writes to w using values as
+// digits. The amount of values must be a power of two.
+func NewEncoder(values []rune, w io.Writer, opts ...StreamOption) (*Encoder, error) {
+	system, err := newStreamSystem(values, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Encoder{
+		system: system,
+		w:      w,
+	}, nil
+}
+
+// Write encodes p to the underlying writer.
+func (e *Encoder) Write(p []byte) (int, error) {
+	if e.lastErr != nil {
+		return 0, e.lastErr
+	}
+	if e.closed {
+		return 0, fmt.Errorf("numeral: write to closed encoder")
+	}
+	k := e.system.bits
+	mask := uint32(1)<<k - 1
+	e.buf = e.buf[:0]
+	for _, b := range p {
+		e.acc = e.acc<<8 | uint32(b)
+		e.nbits += 8
+		for e.nbits >= k {
+			e.nbits -= k
+			e.appendRune(e.system.values[(e.acc>>e.nbits)&mask])
+		}
+	}
+	if _, err := e.w.Write(e.buf); err != nil {
+		e.lastErr = err
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes the remaining bits and the padding to the underlying writer.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.lastErr != nil || e.closed {
+		return e.lastErr
+	}
+	e.closed = true
+	k := e.system.bits
+	e.buf = e.buf[:0]
+	if e.nbits > 0 {
+		// the last digit is filled up with zero bits.
+		e.appendRune(e.system.values[(e.acc<<(k-e.nbits))&(uint32(1)<<k-1)])
+		e.nbits = 0
+	}
+	if e.system.config.hasPadding {
+		for e.digits%e.system.blockDigits != 0 {
+			e.appendRune(e.system.config.padding)
+		}
+	}
+	if _, err := e.w.Write(e.buf); err != nil {
+		e.lastErr = err
+	}
+	return e.lastErr
+}
+
+// appendRune buffers a digit, wrapping the line if needed.
+func (e *Encoder) appendRune(r rune) {
+	if n := e.system.config.lineLength; n > 0 && e.column == n {
+		e.buf = append(e.buf, '\n')
+		e.column = 0
+	}
+	e.buf = appendRune(e.buf, r)
+	e.column++
+	e.digits++
+}
+
+// Decoder decodes digits of a power of two system read from an underlying
+// reader back to bytes. New lines are skipped and decoding stops at the
+// first padding digit.
+type Decoder struct {
+	system  *streamSystem
+	r       *bufio.Reader
+	lookup  map[rune]uint32
+	acc     uint32
+	nbits   uint
+	pos     int
+	out     []byte
+	lastErr error
+}
+
+// NewDecoder creates a stream decoder that reads from r using values as
+// digits. The amount of values must be a power of two.
+func NewDecoder(values []rune, r io.Reader, opts ...StreamOption) (*Decoder, error) {
+	system, err := newStreamSystem(values, opts)
+	if err != nil {
+		return nil, err
+	}
+	lookup := make(map[rune]uint32, len(values))
+	for i, v := range values {
+		lookup[v] = uint32(i)
+	}
+	return &Decoder{
+		system: system,
+		r:      bufio.NewReader(r),
+		lookup: lookup,
+	}, nil
+}
+
+// Read decodes up to len(p) bytes into p.
+func (d *Decoder) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(d.out) > 0 {
+			c := copy(p[n:], d.out)
+			d.out = d.out[c:]
+			n += c
+			continue
+		}
+		if d.lastErr != nil {
+			break
+		}
+		d.lastErr = d.fill(len(p) - n)
+	}
+	if n > 0 {
+		return n, nil
+	}
+	return 0, d.lastErr
+}
+
+// fill decodes digits until at least want bytes are available, the input is
+// over or an error occurs.
+func (d *Decoder) fill(want int) error {
+	k := d.system.bits
+	d.out = d.out[:0]
+	for len(d.out) < want {
+		c, _, err := d.r.ReadRune()
+		if err != nil {
+			return err
+		}
+		d.pos++
+		if c == '\n' || c == '\r' {
+			continue
+		}
+		if d.system.config.hasPadding && c == d.system.config.padding {
+			return io.EOF
+		}
+		i, ok := d.lookup[c]
+		if !ok {
+			return fmt.Errorf("numeral: invalid digit %q at position %d", c, d.pos-1)
+		}
+		d.acc = d.acc<<k | i
+		d.nbits += k
+		if d.nbits >= 8 {
+			d.nbits -= 8
+			d.out = append(d.out, byte(d.acc>>d.nbits))
+		}
+	}
+	return nil
+}
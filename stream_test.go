@@ -0,0 +1,134 @@
+package numeral_test
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/slysterous/numeral"
+)
+
+var (
+	base64Values = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/")
+	base32Values = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ234567")
+	hexValues    = []rune("0123456789abcdef")
+)
+
+func encodeStream(t *testing.T, values []rune, src []byte, opts ...numeral.StreamOption) string {
+	var b bytes.Buffer
+	enc, err := numeral.NewEncoder(values, &b, opts...)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	// write in small chunks to cross block boundaries.
+	for i := 0; i < len(src); i += 7 {
+		end := i + 7
+		if end > len(src) {
+			end = len(src)
+		}
+		if _, err := enc.Write(src[i:end]); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	return b.String()
+}
+
+func decodeStream(t *testing.T, values []rune, s string, opts ...numeral.StreamOption) []byte {
+	dec, err := numeral.NewDecoder(values, strings.NewReader(s), opts...)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	got, err := ioutil.ReadAll(dec)
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	return got
+}
+
+func TestStreamMatchesStandardEncodings(t *testing.T) {
+	inputs := []string{"", "f", "fo", "foo", "foob", "fooba", "foobar", "the quick brown fox jumps over the lazy dog"}
+	for _, in := range inputs {
+		t.Run(in, func(t *testing.T) {
+			src := []byte(in)
+			streamTests := []struct {
+				values []rune
+				opts   []numeral.StreamOption
+				want   string
+			}{
+				{base64Values, []numeral.StreamOption{numeral.WithPadding('=')}, base64.StdEncoding.EncodeToString(src)},
+				{base64Values, nil, base64.RawStdEncoding.EncodeToString(src)},
+				{base32Values, []numeral.StreamOption{numeral.WithPadding('=')}, base32.StdEncoding.EncodeToString(src)},
+				{hexValues, nil, hex.EncodeToString(src)},
+			}
+			for _, tt := range streamTests {
+				got := encodeStream(t, tt.values, src, tt.opts...)
+				if got != tt.want {
+					t.Errorf("got: %s want: %s", got, tt.want)
+				}
+				if decoded := decodeStream(t, tt.values, got, tt.opts...); !bytes.Equal(decoded, src) {
+					t.Errorf("decoded got: %q want: %q", decoded, src)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamLineLength(t *testing.T) {
+	src := []byte("foobarfoobar")
+	got := encodeStream(t, base64Values, src, numeral.WithLineLength(6))
+	if want := "Zm9vYm\nFyZm9v\nYmFy"; got != want {
+		t.Errorf("got: %q want: %q", got, want)
+	}
+	if decoded := decodeStream(t, base64Values, got); !bytes.Equal(decoded, src) {
+		t.Errorf("decoded got: %q want: %q", decoded, src)
+	}
+}
+
+func TestStreamCustomAlphabetRoundTrip(t *testing.T) {
+	alphabets := [][]rune{
+		{'0', '1'},
+		[]rune("0123"),
+		[]rune("01234567"),
+		[]rune("αβγδεζηθικλμνξοπ"),
+	}
+	src := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(src)
+	for _, values := range alphabets {
+		var encoded bytes.Buffer
+		enc, _ := numeral.NewEncoder(values, &encoded, numeral.WithPadding('='), numeral.WithLineLength(76))
+		if _, err := io.Copy(enc, bytes.NewReader(src)); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		enc.Close()
+		dec, _ := numeral.NewDecoder(values, &encoded, numeral.WithPadding('='))
+		var decoded bytes.Buffer
+		if _, err := io.Copy(&decoded, dec); err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		if !bytes.Equal(decoded.Bytes(), src) {
+			t.Errorf("round trip failed for %s", string(values))
+		}
+	}
+}
+
+func TestNewEncoderNotPowerOfTwoThrowsErr(t *testing.T) {
+	if _, err := numeral.NewEncoder(testValues, ioutil.Discard); err == nil {
+		t.Errorf("expected error to be thrown on NewEncoder")
+	}
+}
+
+func TestDecoderInvalidDigitThrowsErr(t *testing.T) {
+	dec, _ := numeral.NewDecoder(hexValues, strings.NewReader("00ag"))
+	if _, err := ioutil.ReadAll(dec); err == nil {
+		t.Errorf("expected error to be thrown on Read")
+	}
+}
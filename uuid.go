@@ -0,0 +1,115 @@
+package numeral
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Base57Values are the digit values of shortuuid, base62 without the easily
+// confused 0, 1, I, O and l.
+var Base57Values = []rune("23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+// EncodeUUID renders a UUID as a short numeral of the system defined by
+// values. The output is zero padded to the amount of digits that any 128 bit
+// value needs, e.g. 22 with Base57Values, so all UUIDs have the same length.
+func EncodeUUID(values []rune, uuid [16]byte) (string, error) {
+	if len(values) < 2 {
+		return "", fmt.Errorf("numeral: at least 2 digit values are needed to encode a UUID, got: %d", len(values))
+	}
+	return encodeFixed(values, uuid[:]), nil
+}
+
+// EncodeUUIDString renders a UUID given in any of its RFC 4122 string forms as
+// a short numeral of the system defined by values.
+func EncodeUUIDString(values []rune, s string) (string, error) {
+	uuid, err := ParseUUID(s)
+	if err != nil {
+		return "", err
+	}
+	return EncodeUUID(values, uuid)
+}
+
+// DecodeUUID decodes a UUID rendered by EncodeUUID.
+func DecodeUUID(values []rune, s string) ([16]byte, error) {
+	var uuid [16]byte
+	if len(values) < 2 {
+		return uuid, fmt.Errorf("numeral: at least 2 digit values are needed to decode a UUID, got: %d", len(values))
+	}
+	err := decodeFixed(values, s, uuid[:])
+	return uuid, err
+}
+
+// ParseUUID parses the RFC 4122 string forms of a UUID: the canonical
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, optionally wrapped in braces or
+// prefixed with urn:uuid:, and the 32 hex digits without hyphens.
+func ParseUUID(s string) ([16]byte, error) {
+	var uuid [16]byte
+	raw := s
+	if len(raw) == 45 && strings.EqualFold(raw[:9], "urn:uuid:") {
+		raw = raw[9:]
+	} else if len(raw) == 38 && raw[0] == '{' && raw[37] == '}' {
+		raw = raw[1:37]
+	}
+	if len(raw) == 36 {
+		if raw[8] != '-' || raw[13] != '-' || raw[18] != '-' || raw[23] != '-' {
+			return uuid, fmt.Errorf("numeral: invalid UUID: %s", s)
+		}
+		raw = raw[:8] + raw[9:13] + raw[14:18] + raw[19:23] + raw[24:]
+	}
+	if len(raw) != 32 {
+		return uuid, fmt.Errorf("numeral: invalid UUID: %s", s)
+	}
+	if _, err := hex.Decode(uuid[:], []byte(raw)); err != nil {
+		return uuid, fmt.Errorf("numeral: invalid UUID: %s", s)
+	}
+	return uuid, nil
+}
+
+// FormatUUID returns the canonical RFC 4122 string form of a UUID.
+func FormatUUID(uuid [16]byte) string {
+	h := hex.EncodeToString(uuid[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// NewUUIDv4 generates a random (version 4) UUID and renders it with
+// Base57Values, or the values given with WithValues.
+func NewUUIDv4(opts ...GeneratorOption) (string, error) {
+	c, err := newGeneratorConfig(Base57Values, opts)
+	if err != nil {
+		return "", err
+	}
+	var uuid [16]byte
+	if _, err := io.ReadFull(c.entropy, uuid[:]); err != nil {
+		return "", err
+	}
+	setUUIDVersion(&uuid, 4)
+	return EncodeUUID(c.values, uuid)
+}
+
+// NewUUIDv7 generates a time ordered (version 7) UUID, a 48 bit millisecond
+// timestamp followed by random bits, and renders it with Base57Values, or
+// the values given with WithValues.
+func NewUUIDv7(opts ...GeneratorOption) (string, error) {
+	c, err := newGeneratorConfig(Base57Values, opts)
+	if err != nil {
+		return "", err
+	}
+	var uuid [16]byte
+	ms := uint64(c.clock().UnixNano() / int64(time.Millisecond))
+	binary.BigEndian.PutUint64(uuid[:8], ms<<16)
+	if _, err := io.ReadFull(c.entropy, uuid[6:]); err != nil {
+		return "", err
+	}
+	setUUIDVersion(&uuid, 7)
+	return EncodeUUID(c.values, uuid)
+}
+
+// setUUIDVersion sets the version and the RFC 4122 variant bits of a UUID.
+func setUUIDVersion(uuid *[16]byte, version byte) {
+	uuid[6] = uuid[6]&0x0f | version<<4
+	uuid[8] = uuid[8]&0x3f | 0x80
+}
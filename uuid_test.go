@@ -0,0 +1,107 @@
+package numeral_test
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/slysterous/numeral"
+)
+
+func TestEncodeUUIDString(t *testing.T) {
+	forms := []string{
+		"3b1f8b40-222c-4a6e-b77e-779d5a94e21c",
+		"3B1F8B40-222C-4A6E-B77E-779D5A94E21C",
+		"{3b1f8b40-222c-4a6e-b77e-779d5a94e21c}",
+		"urn:uuid:3b1f8b40-222c-4a6e-b77e-779d5a94e21c",
+		"3b1f8b40222c4a6eb77e779d5a94e21c",
+	}
+	var want string
+	for _, s := range forms {
+		t.Run(s, func(t *testing.T) {
+			got, err := numeral.EncodeUUIDString(numeral.Base57Values, s)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if len(got) != 22 {
+				t.Errorf("got: %d digits want: 22", len(got))
+			}
+			if want == "" {
+				want = got
+			}
+			if got != want {
+				t.Errorf("got: %s want: %s", got, want)
+			}
+			uuid, err := numeral.DecodeUUID(numeral.Base57Values, got)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if numeral.FormatUUID(uuid) != forms[0] {
+				t.Errorf("got: %s want: %s", numeral.FormatUUID(uuid), forms[0])
+			}
+		})
+	}
+}
+
+func TestEncodeUUIDIsZeroPadded(t *testing.T) {
+	encodeTests := []struct {
+		values []rune
+		uuid   [16]byte
+		want   string
+	}{
+		{numeral.Base57Values, [16]byte{}, "2222222222222222222222"},
+		{numeral.Base57Values, [16]byte{15: 1}, "2222222222222222222223"},
+		{hexValues, [16]byte{15: 0xff}, "000000000000000000000000000000ff"},
+	}
+	for _, tt := range encodeTests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := numeral.EncodeUUID(tt.values, tt.uuid)
+			if err != nil {
+				t.Fatalf("expected nil got err: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got: %s want: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUUIDInvalid(t *testing.T) {
+	invalid := []string{"", "3b1f8b40-222c-4a6e-b77e", "3b1f8b40+222c-4a6e-b77e-779d5a94e21c", "zz1f8b40222c4a6eb77e779d5a94e21c"}
+	for _, s := range invalid {
+		if _, err := numeral.ParseUUID(s); err == nil {
+			t.Errorf("expected error to be thrown on ParseUUID(%q)", s)
+		}
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	entropy := bytes.NewReader(bytes.Repeat([]byte{0xff}, 32))
+	v4, err := numeral.NewUUIDv4(numeral.WithEntropy(entropy))
+	if err != nil {
+		t.Fatalf("expected nil got err: %v", err)
+	}
+	uuid, _ := numeral.DecodeUUID(numeral.Base57Values, v4)
+	if got, want := numeral.FormatUUID(uuid), "ffffffff-ffff-4fff-bfff-ffffffffffff"; got != want {
+		t.Errorf("got: %s want: %s", got, want)
+	}
+
+	now := time.Unix(1700000000, 0)
+	var ids []string
+	for i := 0; i < 10; i++ {
+		id, err := numeral.NewUUIDv7(numeral.WithClock(fakeClock(&now)))
+		if err != nil {
+			t.Fatalf("expected nil got err: %v", err)
+		}
+		ids = append(ids, id)
+		now = now.Add(time.Millisecond)
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Errorf("expected version 7 UUIDs to be sorted: %v", ids)
+	}
+	uuid, _ = numeral.DecodeUUID(numeral.Base57Values, ids[0])
+	if uuid[6]>>4 != 7 || uuid[8]>>6 != 2 {
+		t.Errorf("got version %d variant %d want: 7 2", uuid[6]>>4, uuid[8]>>6)
+	}
+}